@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/localsock"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/sshagent"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// agentCmd represents the agent command
+var agentCmd = &cobra.Command{
+	Use:   "agent",
+	Short: "Run SSH agent backed by the vault",
+	Long: `Run SSH agent that serves SSH keys stored in the vault over a Unix socket.
+Use the printed SSH_AUTH_SOCK value to point ssh at the agent.
+Send SIGHUP to reload keys from the vault.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "agent"
+		log := logger.GetInstance().Log.With("op", op)
+		cfg := config.GetInstance().Config
+
+		socket, err := cmd.Flags().GetString("socket")
+		if err != nil {
+			log.Error("Error reading socket flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if socket == "" {
+			socket = cfg.Agent.Socket
+		}
+		if socket == "" {
+			socket = defaultAgentSocket()
+		}
+
+		confirm := cfg.Agent.Confirm
+		if cmd.Flags().Changed("confirm") {
+			if confirm, err = cmd.Flags().GetBool("confirm"); err != nil {
+				log.Error("Error reading confirm flag: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		var confirmFunc sshagent.ConfirmFunc
+		if confirm {
+			confirmFunc = sshagent.AskConfirm
+		}
+		sshAgent := sshagent.New(log, confirmFunc)
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		if err := loadAgentKeys(context.Background(), keeperClient, sshAgent); err != nil {
+			log.Error("Failed to load SSH keys: ", slog.String("error", err.Error()))
+			return
+		}
+
+		listener, err := listenAgentSocket(socket)
+		if err != nil {
+			log.Error("Failed to listen agent socket: ", slog.String("error", err.Error()))
+			return
+		}
+		defer os.Remove(socket)
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+		go func() {
+			for sig := range signals {
+				if sig == syscall.SIGHUP {
+					if err := loadAgentKeys(context.Background(), keeperClient, sshAgent); err != nil {
+						log.Error("Failed to reload SSH keys: ", slog.String("error", err.Error()))
+					}
+					continue
+				}
+				listener.Close()
+				return
+			}
+		}()
+
+		fmt.Printf("SSH_AUTH_SOCK=%s; export SSH_AUTH_SOCK;\n", socket)
+		if err := sshAgent.Serve(listener); err != nil {
+			log.Error("Agent stopped: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(agentCmd)
+
+	agentCmd.Flags().String("socket", "", "Agent Unix socket path")
+	agentCmd.Flags().Bool("confirm", false, "Ask for confirmation on every key use")
+}
+
+// loadAgentKeys загружает ключи SSH из хранилища в агента
+func loadAgentKeys(ctx context.Context, keeperClient *app.KeeperClient, sshAgent *sshagent.Agent) error {
+	const op = "agent.loadKeys"
+	log := logger.GetInstance().Log.With("op", op)
+
+	secrets, err := listAllItems(ctx, keeperClient, &v1.ListItemsRequestV1{
+		Filter:         &v1.ListItemsRequestV1_Filter{Type: string(vaulttypes.SSHKey{}.Type())},
+		IncludeContent: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]sshagent.Key, 0, len(secrets))
+	for _, info := range secrets {
+		secret, err := decryptSecret(info.GetContent())
+		if err != nil {
+			log.Warn("Failed to decrypt SSH key: ",
+				slog.String("name", info.GetName()),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		key, ok := secret.(vaulttypes.SSHKey)
+		if !ok {
+			continue
+		}
+		keys = append(keys, sshagent.Key{Name: info.GetName(), Secret: key})
+	}
+
+	if err := sshAgent.Load(keys); err != nil {
+		return err
+	}
+
+	log.Info("SSH keys loaded", slog.Int("count", len(keys)))
+	return nil
+}
+
+// listenAgentSocket создает сокет агента, доступный только владельцу
+func listenAgentSocket(socket string) (net.Listener, error) {
+	return localsock.Listen(socket)
+}
+
+// defaultAgentSocket возвращает путь сокета агента по умолчанию
+func defaultAgentSocket() string {
+	return filepath.Join(localsock.RuntimeDir(), "ssh-agent.sock")
+}
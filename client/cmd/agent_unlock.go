@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/session"
+	"github.com/ajugalushkin/goph-keeper/client/internal/token"
+)
+
+// defaultSessionAutoLock время бездействия до блокировки сессии по умолчанию
+const defaultSessionAutoLock = 15 * time.Minute
+
+// agentUnlockCmd represents the agent unlock command
+var agentUnlockCmd = &cobra.Command{
+	Use:   "unlock",
+	Short: "Unlock the vault for the following commands",
+	Long: `Ask for the master password, derive the vault key and start a background
+session agent that keeps the key in locked memory behind a Unix socket.
+keep commands check the session and extend it, the key itself never
+leaves the agent.
+
+The session locks after --autolock (session.autolock in the config, 15m
+by default) without keep commands, when the screen is locked (systemd-logind
+sessions) or on "lock". Use --autolock 0 to lock only explicitly.
+With session.require in the config keep commands refuse to run while the
+vault is locked.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "agent_unlock"
+		log := logger.GetInstance().Log.With("op", op)
+
+		autoLock := config.GetInstance().Config.Session.AutoLock
+		if autoLock == 0 || cmd.Flags().Changed("autolock") {
+			var err error
+			if autoLock, err = cmd.Flags().GetDuration("autolock"); err != nil {
+				log.Error("Error reading autolock flag: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		screenLock, err := cmd.Flags().GetBool("screen-lock")
+		if err != nil {
+			log.Error("Error reading screen-lock flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		socket := sessionSocket()
+		if expires, err := session.NewClient(socket).Status(); err == nil {
+			printSessionStatus(expires)
+			return
+		}
+
+		account := activeProfile.Account
+		if account == "" {
+			accessToken, err := tokenStorage.Load()
+			if err != nil {
+				log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+				return
+			}
+			if account, err = token.Email(accessToken); err != nil {
+				log.Error("Unable to read account from token, run auth login: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		password, err := readSecretFlag(cmd, "password", "Master password for "+account, false)
+		if err != nil {
+			log.Error("Error reading password: ", slog.String("error", err.Error()))
+			return
+		}
+
+		authConn, err := app.GetAuthConnection()
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+
+		// Пароль проверяется входом на сервер, заодно обновляется токен
+		accessToken, err := app.NewAuthClient(authConn).Login(context.Background(), account, password)
+		if err != nil {
+			log.Error("Failed to unlock: ", slog.String("error", err.Error()))
+			return
+		}
+		if err := tokenStorage.Save(accessToken); err != nil {
+			log.Error("Failed to store access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		secret := []byte(password)
+		key := session.DeriveKey(secret, account)
+		wipeBytes(secret)
+		err = startSessionAgent(socket, key, autoLock, screenLock)
+		wipeBytes(key)
+		if err != nil {
+			log.Error("Failed to start session agent: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if autoLock > 0 {
+			fmt.Printf("Vault unlocked, locks after %s without use\n", autoLock)
+		} else {
+			fmt.Println("Vault unlocked until \"lock\"")
+		}
+	},
+}
+
+// agentSessionCmd процесс агента сессии, запускаемый agent unlock
+var agentSessionCmd = &cobra.Command{
+	Use:    "session",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "agent_session"
+		logger.UseStderr()
+		log := logger.GetInstance().Log.With("op", op)
+
+		socket, err := cmd.Flags().GetString("socket")
+		if err != nil {
+			sessionFail(err)
+		}
+		autoLock, err := cmd.Flags().GetDuration("autolock")
+		if err != nil {
+			sessionFail(err)
+		}
+		screenLock, err := cmd.Flags().GetBool("screen-lock")
+		if err != nil {
+			sessionFail(err)
+		}
+
+		// Агент переживает закрытие терминала, в котором запущен unlock
+		signal.Ignore(syscall.SIGHUP)
+
+		line, err := bufio.NewReader(os.Stdin).ReadBytes('\n')
+		if err != nil {
+			sessionFail(err)
+		}
+		encoded := bytes.TrimSpace(line)
+		key := make([]byte, hex.DecodedLen(len(encoded)))
+		_, err = hex.Decode(key, encoded)
+		wipeBytes(line)
+		if err != nil {
+			wipeBytes(key)
+			sessionFail(err)
+		}
+
+		opts := session.Options{AutoLock: autoLock, Log: log}
+		if screenLock {
+			opts.ScreenLocked = session.ScreenLockDetector()
+		}
+		agent := session.New(key, opts)
+
+		listener, err := listenAgentSocket(socket)
+		if err != nil {
+			agent.Lock()
+			sessionFail(err)
+		}
+		defer os.Remove(socket)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			select {
+			case <-ctx.Done():
+				agent.Lock()
+			case <-agent.Done():
+			}
+		}()
+
+		fmt.Println("ready")
+		os.Stdout.Close()
+
+		if err := agent.Serve(listener); err != nil {
+			log.Error("Session agent stopped: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	agentCmd.AddCommand(agentUnlockCmd)
+	agentCmd.AddCommand(agentSessionCmd)
+
+	agentUnlockCmd.Flags().Duration("autolock", defaultSessionAutoLock, "Lock after this idle time, 0 to disable")
+	agentUnlockCmd.Flags().Bool("screen-lock", true, "Lock when the screen is locked")
+	addSecretFlag(agentUnlockCmd, "password", "", "Master password", true)
+
+	agentSessionCmd.Flags().String("socket", "", "Session agent socket")
+	agentSessionCmd.Flags().Duration("autolock", defaultSessionAutoLock, "Lock after this idle time")
+	agentSessionCmd.Flags().Bool("screen-lock", true, "Lock when the screen is locked")
+}
+
+// sessionSocket возвращает сокет агента сессии выбранного профиля
+func sessionSocket() string {
+	name := activeProfile.Name
+	if name == "" {
+		name = token.DefaultProfile
+	}
+	return session.DefaultSocket(name)
+}
+
+// unlockVault продлевает сессию агента перед командами keep. Без агента
+// команды выполняются, если session.require не установлен.
+func unlockVault(cmd *cobra.Command) error {
+	if !isKeepCommand(cmd) {
+		return nil
+	}
+
+	if _, err := session.NewClient(sessionSocket()).Touch(); err != nil {
+		if config.GetInstance().Config.Session.Require {
+			return fmt.Errorf("%w, run \"agent unlock\"", err)
+		}
+	}
+	return nil
+}
+
+// isKeepCommand проверяет, что cmd входит в поддерево keep
+func isKeepCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == keepCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// startSessionAgent запускает агента сессии в фоне и передает ему ключ
+// через stdin, чтобы ключ не попал в аргументы процесса
+func startSessionAgent(socket string, key []byte, autoLock time.Duration, screenLock bool) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"agent", "session",
+		"--socket", socket,
+		"--autolock", autoLock.String(),
+		fmt.Sprintf("--screen-lock=%t", screenLock),
+	}
+	if cfgFile != "" {
+		path, err := filepath.Abs(cfgFile)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--config", path)
+	}
+	if activeProfile.Name != "" {
+		args = append(args, "--profile", activeProfile.Name)
+	}
+
+	child := exec.Command(exe, args...)
+	stdin, err := child.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := child.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := child.Start(); err != nil {
+		return err
+	}
+
+	encoded := make([]byte, hex.EncodedLen(len(key))+1)
+	hex.Encode(encoded, key)
+	encoded[len(encoded)-1] = '\n'
+	_, err = stdin.Write(encoded)
+	wipeBytes(encoded)
+	stdin.Close()
+	if err != nil {
+		_ = child.Process.Kill()
+		return err
+	}
+
+	ready, err := bufio.NewReader(stdout).ReadString('\n')
+	if strings.TrimSpace(ready) != "ready" {
+		_ = child.Wait()
+		if err == nil {
+			err = errors.New(strings.TrimSpace(ready))
+		}
+		return fmt.Errorf("session agent did not start: %w", err)
+	}
+	return child.Process.Release()
+}
+
+// sessionFail сообщает запустившей команде об ошибке агента сессии
+func sessionFail(err error) {
+	fmt.Println(err.Error())
+	os.Exit(1)
+}
+
+// printSessionStatus выводит время блокировки разблокированной сессии
+func printSessionStatus(expires time.Time) {
+	if expires.IsZero() {
+		fmt.Println("Vault is already unlocked until \"lock\"")
+		return
+	}
+	fmt.Printf("Vault is already unlocked, locks at %s without use\n", expires.Format(time.Kitchen))
+}
+
+// wipeBytes затирает ключ или пароль в памяти
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"context"
-	"log/slog"
 
 	"github.com/spf13/cobra"
 
@@ -23,21 +22,36 @@ var loginCmd = &cobra.Command{
 		if err != nil {
 			log.Error("Error while getting email", "error", err)
 		}
+		if email == "" {
+			email = activeProfile.Account
+		}
+		if email == "" {
+			log.Error("Email is required, use --email or set the profile account")
+			return
+		}
 
-		password, err := cmd.Flags().GetString("password")
+		password, err := readSecretFlag(cmd, "password", "Password", false)
 		if err != nil {
-			log.Error("Error while getting password")
+			log.Error("Error while getting password", "error", err)
+			return
 		}
 
-		authClient := app.NewAuthClient(app.GetAuthConnection())
+		conn, err := app.GetAuthConnection()
+		if err != nil {
+			log.Error("Error while connecting to server", "error", err)
+			return
+		}
+		authClient := app.NewAuthClient(conn)
 
 		token, err := authClient.Login(context.Background(), email, password)
 		if err != nil {
 			log.Error("Error while login", "error", err)
+			return
 		}
 
 		if err := tokenStorage.Save(token); err != nil {
-			log.Error("Failed to store access token")
+			log.Error("Failed to store access token", "error", err)
+			return
 		}
 
 		log.Info("Login success")
@@ -47,12 +61,6 @@ var loginCmd = &cobra.Command{
 func init() {
 	authCmd.AddCommand(loginCmd)
 
-	loginCmd.Flags().StringP("email", "e", "", "User Email")
-	if err := loginCmd.MarkFlagRequired("email"); err != nil {
-		slog.Error("Error marking email as required")
-	}
-	loginCmd.Flags().StringP("password", "p", "", "User password")
-	if err := loginCmd.MarkFlagRequired("password"); err != nil {
-		slog.Error("Error marking password as required")
-	}
+	loginCmd.Flags().StringP("email", "e", "", "User Email, the profile account by default")
+	addSecretFlag(loginCmd, "password", "p", "User password", true)
 }
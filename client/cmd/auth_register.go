@@ -23,12 +23,18 @@ var registerCmd = &cobra.Command{
 			log.Error("Error getting email", "error", err)
 		}
 
-		password, err := cmd.Flags().GetString("password")
+		password, err := readSecretFlag(cmd, "password", "Password", true)
 		if err != nil {
 			log.Error("Error getting password", "error", err)
+			return
 		}
 
-		authClient := app.NewAuthClient(app.GetAuthConnection())
+		conn, err := app.GetAuthConnection()
+		if err != nil {
+			log.Error("Error connecting to server", "error", err)
+			return
+		}
+		authClient := app.NewAuthClient(conn)
 
 		err = authClient.Register(context.Background(), email, password)
 		if err != nil {
@@ -44,8 +50,5 @@ func init() {
 	if err := registerCmd.MarkFlagRequired("email"); err != nil {
 		slog.Error("Error setting email flag", "error", err)
 	}
-	registerCmd.Flags().StringP("password", "p", "", "User password")
-	if err := registerCmd.MarkFlagRequired("password"); err != nil {
-		slog.Error("Error setting password flag", "error", err)
-	}
+	addSecretFlag(registerCmd, "password", "p", "User password", true)
 }
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/cache"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// completionTimeout ограничивает запрос списка секретов при дополнении,
+// чтобы недоступный сервер не подвешивал оболочку
+const completionTimeout = 3 * time.Second
+
+// completionCacheTTL срок, в течение которого кэш считается актуальным
+// для дополнения
+const completionCacheTTL = 5 * time.Minute
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate shell completion script",
+	Long: `Generate the completion script for the given shell.
+Secret names, types and folders are completed from the local cache
+(see "keep search"). The server is asked instead when the cache is
+older than 5 minutes or has nothing matching, the cache is used if the
+server is not available.
+Profile names are completed from the configured profiles.
+
+Bash (requires bash-completion):
+  source <(gophkeeper_client completion bash)
+
+Zsh:
+  gophkeeper_client completion zsh > "${fpath[1]}/_gophkeeper_client"
+
+Fish:
+  gophkeeper_client completion fish > ~/.config/fish/completions/gophkeeper_client.fish
+
+PowerShell:
+  gophkeeper_client completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "completion"
+		log := logger.GetInstance().Log.With("op", op)
+
+		root := cmd.Root()
+		var err error
+		switch args[0] {
+		case "bash":
+			err = root.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			err = root.GenZshCompletion(os.Stdout)
+		case "fish":
+			err = root.GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			err = root.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+		if err != nil {
+			log.Error("Failed to generate completion: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+}
+
+// registerFlagCompletion регистрирует дополнение значений флага
+func registerFlagCompletion(
+	cmd *cobra.Command,
+	flag string,
+	complete func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective),
+) {
+	if err := cmd.RegisterFlagCompletionFunc(flag, complete); err != nil {
+		slog.Error("Error registering flag completion: ",
+			slog.String("command", cmd.CommandPath()),
+			slog.String("flag", flag),
+			slog.String("error", err.Error()))
+	}
+}
+
+// completeSecretArg дополняет имя секрета в первом аргументе команды
+func completeSecretArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeSecretNames(cmd, args, toComplete)
+}
+
+// completeSecretNames дополняет имена секретов, описанием служит тип
+func completeSecretNames(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	items := completionItems(cmd, func(item cache.Item) bool {
+		return strings.HasPrefix(item.Name, toComplete)
+	})
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if !strings.HasPrefix(item.Name, toComplete) {
+			continue
+		}
+		if item.Type != "" {
+			names = append(names, item.Name+"\t"+item.Type)
+		} else {
+			names = append(names, item.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFolders дополняет папки секретов
+func completeFolders(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	seen := make(map[string]bool)
+	folders := make([]string, 0)
+	match := func(item cache.Item) bool {
+		return item.Folder != "" && strings.HasPrefix(item.Folder, toComplete)
+	}
+	for _, item := range completionItems(cmd, match) {
+		if item.Folder == "" || seen[item.Folder] || !strings.HasPrefix(item.Folder, toComplete) {
+			continue
+		}
+		seen[item.Folder] = true
+		folders = append(folders, item.Folder)
+	}
+	sort.Strings(folders)
+	return folders, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSecretTypes дополняет зарегистрированные типы секретов,
+// включая шаблоны из конфигурации
+func completeSecretTypes(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	types := make([]string, 0)
+	for _, schema := range vaulttypes.Schemas() {
+		if strings.HasPrefix(string(schema.Type), toComplete) {
+			types = append(types, string(schema.Type))
+		}
+	}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProfileArg дополняет имя профиля в первом аргументе команды
+func completeProfileArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeProfiles(cmd, args, toComplete)
+}
+
+// completeProfiles дополняет имена профилей, описанием служит адрес сервера
+func completeProfiles(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	profiles, err := openProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := make([]string, 0)
+	for _, entry := range profiles.List() {
+		if strings.HasPrefix(entry.Name, toComplete) {
+			names = append(names, entry.Name+"\t"+entry.Address)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionItems возвращает секреты из локального кэша. Если кэш
+// устарел или в нем нет подходящих секретов, список запрашивается
+// с сервера, а при недоступном сервере используется кэш. Ошибки не
+// выводятся, чтобы не испортить вывод дополнения.
+func completionItems(cmd *cobra.Command, match func(cache.Item) bool) []cache.Item {
+	var cached []cache.Item
+	if c, err := openCache(); err == nil {
+		cached = c.List()
+		if time.Since(c.SyncedAt) < completionCacheTTL && slices.ContainsFunc(cached, match) {
+			return cached
+		}
+	}
+
+	items, err := serverCompletionItems(cmd)
+	if err != nil {
+		return cached
+	}
+	return items
+}
+
+// serverCompletionItems запрашивает список секретов с сервера
+func serverCompletionItems(cmd *cobra.Command) ([]cache.Item, error) {
+	// Хуки root не выполняются для дополняемой команды
+	logger.UseStderr()
+	if err := applyProfile(cmd); err != nil {
+		return nil, err
+	}
+	accessToken, err := tokenStorage.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+
+	conn, err := app.GetKeeperConnection(accessToken)
+	if err != nil {
+		return nil, err
+	}
+	keeperClient := app.NewKeeperClient(conn)
+	secrets, err := listAllItems(ctx, keeperClient, &v1.ListItemsRequestV1{})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]cache.Item, 0, len(secrets))
+	for _, info := range secrets {
+		items = append(items, cache.Item{
+			Name:   info.GetName(),
+			Type:   info.GetType(),
+			Folder: info.GetFolder(),
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/dockercred"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// dockerCredentialCmd represents the docker-credential command
+var dockerCredentialCmd = &cobra.Command{
+	Use:   "docker-credential <get|store|erase|list>",
+	Short: "Docker credential helper backed by the vault",
+	Long: `Docker credential helper that keeps registry logins as credentials secrets.
+Link or copy the client binary as ` + dockercred.Binary + ` somewhere
+in PATH and set "credsStore": "gophkeeper" in ~/.docker/config.json.
+
+Secrets are named <prefix><registry>, the prefix is set with --prefix or
+docker.prefix in the config, "` + dockercred.DefaultPrefix + `" by default.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"get", "store", "erase", "list", "version"},
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "docker_credential"
+		// stdout занят протоколом docker
+		logger.UseStderr()
+		log := logger.GetInstance().Log.With("op", op)
+
+		prefix := config.GetInstance().Config.Docker.Prefix
+		if prefix == "" || cmd.Flags().Changed("prefix") {
+			var err error
+			if prefix, err = cmd.Flags().GetString("prefix"); err != nil {
+				log.Error("Error reading prefix flag: ", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}
+
+		var action func(context.Context, *app.KeeperClient, string) error
+		switch args[0] {
+		case "get":
+			action = dockerCredentialGet
+		case "store":
+			action = dockerCredentialStore
+		case "erase":
+			action = dockerCredentialErase
+		case "list":
+			action = dockerCredentialList
+		case "version":
+			fmt.Println(dockercred.Binary)
+			return
+		default:
+			dockerCredentialFail(log, fmt.Errorf("unknown credential action %q", args[0]))
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			dockerCredentialFail(log, err)
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			dockerCredentialFail(log, err)
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		if err := action(context.Background(), keeperClient, prefix); err != nil {
+			dockerCredentialFail(log, err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dockerCredentialCmd)
+
+	dockerCredentialCmd.Flags().String("prefix", dockercred.DefaultPrefix, "Secret name prefix")
+}
+
+// ExecuteDockerCredential запускает клиент в режиме помощника docker,
+// когда он вызван как docker-credential-gophkeeper
+func ExecuteDockerCredential(args []string) {
+	preloadConfig()
+
+	rootCmd.SetArgs(append([]string{dockerCredentialCmd.Name()}, args...))
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// dockerCredentialFail сообщает об ошибке так, как ждет docker:
+// текст ошибки в stdout и ненулевой код завершения
+func dockerCredentialFail(log *slog.Logger, err error) {
+	log.Debug("Docker credential helper failed: ", slog.String("error", err.Error()))
+	fmt.Println(err.Error())
+	os.Exit(1)
+}
+
+func dockerCredentialGet(ctx context.Context, keeperClient *app.KeeperClient, prefix string) error {
+	serverURL, err := dockercred.ReadServerURL(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	item, err := keeperClient.GetItem(ctx, &v1.GetItemRequestV1{Name: dockercred.Name(prefix, serverURL)})
+	if status.Code(err) == codes.NotFound {
+		return dockercred.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	credentials, err := decryptDockerCredentials(item.GetContent())
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(dockercred.Credentials{
+		ServerURL: serverURL,
+		Username:  credentials.Login,
+		Secret:    credentials.Password,
+	})
+}
+
+func dockerCredentialStore(ctx context.Context, keeperClient *app.KeeperClient, prefix string) error {
+	creds, err := dockercred.ReadCredentials(os.Stdin)
+	if err != nil {
+		return err
+	}
+	name := dockercred.Name(prefix, creds.ServerURL)
+
+	item, err := keeperClient.GetItem(ctx, &v1.GetItemRequestV1{Name: name})
+	if status.Code(err) == codes.NotFound {
+		req, err := buildCreateItemRequest(name, "", []string{dockercred.Tag}, vaulttypes.Credentials{
+			Login:    creds.Username,
+			Password: creds.Secret,
+			URL:      creds.ServerURL,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = keeperClient.CreateItem(ctx, req)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	credentials, err := decryptDockerCredentials(item.GetContent())
+	if err != nil {
+		return err
+	}
+	if credentials.Login == creds.Username && credentials.Password == creds.Secret {
+		return nil
+	}
+
+	credentials.Login, credentials.Password = creds.Username, creds.Secret
+	content, err := encryptSecret(credentials)
+	if err != nil {
+		return err
+	}
+	_, err = keeperClient.UpdateItem(ctx, &v1.UpdateItemRequestV1{
+		Name:    name,
+		Content: content,
+		Version: item.GetVersion(),
+	})
+	return err
+}
+
+func dockerCredentialErase(ctx context.Context, keeperClient *app.KeeperClient, prefix string) error {
+	serverURL, err := dockercred.ReadServerURL(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	_, err = keeperClient.DeleteItem(ctx, &v1.DeleteItemRequestV1{Name: dockercred.Name(prefix, serverURL)})
+	if status.Code(err) == codes.NotFound {
+		return dockercred.ErrNotFound
+	}
+	return err
+}
+
+// dockerCredentialList выводит адреса реестров и логины для них
+func dockerCredentialList(ctx context.Context, keeperClient *app.KeeperClient, prefix string) error {
+	secrets, err := listAllItems(ctx, keeperClient, &v1.ListItemsRequestV1{
+		Filter: &v1.ListItemsRequestV1_Filter{
+			Type:       string(vaulttypes.Credentials{}.Type()),
+			Tag:        dockercred.Tag,
+			NamePrefix: prefix,
+		},
+		IncludeContent: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	list := make(map[string]string, len(secrets))
+	for _, info := range secrets {
+		credentials, err := decryptDockerCredentials(info.GetContent())
+		if err != nil {
+			return fmt.Errorf("secret %s: %w", info.GetName(), err)
+		}
+		list[credentials.URL] = credentials.Login
+	}
+	return json.NewEncoder(os.Stdout).Encode(list)
+}
+
+func decryptDockerCredentials(content []byte) (vaulttypes.Credentials, error) {
+	secret, err := decryptSecret(content)
+	if err != nil {
+		return vaulttypes.Credentials{}, err
+	}
+	credentials, ok := secret.(vaulttypes.Credentials)
+	if !ok {
+		return vaulttypes.Credentials{}, fmt.Errorf("secret is %s, not credentials", secret.Type())
+	}
+	return credentials, nil
+}
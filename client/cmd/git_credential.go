@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/gitcred"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// gitCredentialCmd represents the git-credential command
+var gitCredentialCmd = &cobra.Command{
+	Use:   "git-credential <get|store|erase>",
+	Short: "Git credential helper backed by the vault",
+	Long: `Git credential helper that keeps credentials as credentials secrets.
+Configure git to use it:
+
+  git config --global credential.helper "!gophkeeper_client git-credential"
+
+Secret names are built from the name template (--name-template or git.name
+in the config), a Go text/template with the fields .Protocol, .Host, .Path
+and .Username. The default is ` + gitcred.DefaultNameTemplate + `.
+Git sends the path only with credential.useHttpPath enabled; a secret for
+the whole host is used when there is none for the path.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"get", "store", "erase"},
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "git_credential"
+		// stdout занят протоколом git
+		logger.UseStderr()
+		log := logger.GetInstance().Log.With("op", op)
+
+		nameTemplate := config.GetInstance().Config.Git.Name
+		if cmd.Flags().Changed("name-template") {
+			var err error
+			if nameTemplate, err = cmd.Flags().GetString("name-template"); err != nil {
+				log.Error("Error reading name-template flag: ", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}
+		namer, err := gitcred.NewNamer(nameTemplate)
+		if err != nil {
+			log.Error("Invalid name template: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		cred, err := gitcred.Read(os.Stdin)
+		if err != nil {
+			log.Error("Failed to read credential: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		var action func(context.Context, *app.KeeperClient, *gitcred.Namer, gitcred.Credential) error
+		switch args[0] {
+		case "get":
+			action = gitCredentialGet
+		case "store":
+			action = gitCredentialStore
+		case "erase":
+			action = gitCredentialErase
+		default:
+			// Неизвестные операции помощник должен молча игнорировать
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		if err := action(context.Background(), keeperClient, namer, cred); err != nil {
+			log.Error("Git credential "+args[0]+" failed: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gitCredentialCmd)
+
+	gitCredentialCmd.Flags().String("name-template", gitcred.DefaultNameTemplate, "Secret name template")
+}
+
+// gitCredentialGet отдает git логин и пароль. Если секрета нет,
+// ничего не выводится, и git спрашивает пользователя.
+func gitCredentialGet(ctx context.Context, keeperClient *app.KeeperClient, namer *gitcred.Namer, cred gitcred.Credential) error {
+	found, err := findGitCredentials(ctx, keeperClient, namer, cred)
+	if err != nil || found == nil {
+		return err
+	}
+	return gitcred.Write(os.Stdout, found.secret.Login, found.secret.Password)
+}
+
+// gitCredentialStore сохраняет учетные данные, которые git успешно использовал
+func gitCredentialStore(ctx context.Context, keeperClient *app.KeeperClient, namer *gitcred.Namer, cred gitcred.Credential) error {
+	if cred.Username == "" || cred.Password == "" {
+		return nil
+	}
+
+	name, err := namer.Name(cred)
+	if err != nil {
+		return err
+	}
+
+	item, err := keeperClient.GetItem(ctx, &v1.GetItemRequestV1{Name: name})
+	if status.Code(err) == codes.NotFound {
+		req, err := buildCreateItemRequest(name, "", []string{"git"}, vaulttypes.Credentials{
+			Login:    cred.Username,
+			Password: cred.Password,
+			URL:      cred.URL(),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = keeperClient.CreateItem(ctx, req)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	secret, err := decryptSecret(item.GetContent())
+	if err != nil {
+		return err
+	}
+	credentials, ok := secret.(vaulttypes.Credentials)
+	if !ok {
+		return fmt.Errorf("secret %s is %s, not credentials", name, secret.Type())
+	}
+	if credentials.Login == cred.Username && credentials.Password == cred.Password {
+		return nil
+	}
+
+	credentials.Login, credentials.Password = cred.Username, cred.Password
+	if credentials.URL == "" {
+		credentials.URL = cred.URL()
+	}
+	content, err := encryptSecret(credentials)
+	if err != nil {
+		return err
+	}
+	_, err = keeperClient.UpdateItem(ctx, &v1.UpdateItemRequestV1{
+		Name:    name,
+		Content: content,
+		Version: item.GetVersion(),
+	})
+	return err
+}
+
+// gitCredentialErase удаляет учетные данные, которые git отклонил.
+// Секрет удаляется, только если он не менялся с тех пор, как git его получил.
+func gitCredentialErase(ctx context.Context, keeperClient *app.KeeperClient, namer *gitcred.Namer, cred gitcred.Credential) error {
+	found, err := findGitCredentials(ctx, keeperClient, namer, cred)
+	if err != nil || found == nil {
+		return err
+	}
+	if cred.Password != "" && cred.Password != found.secret.Password {
+		return nil
+	}
+
+	_, err = keeperClient.DeleteItem(ctx, &v1.DeleteItemRequestV1{
+		Name:    found.info.GetName(),
+		Version: found.info.GetVersion(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+type gitCredentials struct {
+	info   *v1.SecretInfo
+	secret vaulttypes.Credentials
+}
+
+// findGitCredentials ищет учетные данные по именам в порядке предпочтения.
+// Если git передал логин, подходят только секреты с этим логином.
+func findGitCredentials(
+	ctx context.Context,
+	keeperClient *app.KeeperClient,
+	namer *gitcred.Namer,
+	cred gitcred.Credential,
+) (*gitCredentials, error) {
+	names, err := namer.Names(cred)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := keeperClient.BatchGetItems(ctx, &v1.BatchGetItemsRequestV1{Names: names})
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make(map[string]*v1.SecretInfo, len(resp.GetSecrets()))
+	for _, info := range resp.GetSecrets() {
+		infos[info.GetName()] = info
+	}
+
+	for _, name := range names {
+		info, ok := infos[name]
+		if !ok {
+			continue
+		}
+		secret, err := decryptSecret(info.GetContent())
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", name, err)
+		}
+		credentials, ok := secret.(vaulttypes.Credentials)
+		if !ok {
+			continue
+		}
+		if cred.Username != "" && credentials.Login != cred.Username {
+			continue
+		}
+		return &gitCredentials{info: info, secret: credentials}, nil
+	}
+	return nil, nil
+}
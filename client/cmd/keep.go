@@ -2,11 +2,19 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
+	"log/slog"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
 
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/cache"
+	"github.com/ajugalushkin/goph-keeper/client/internal/search"
+	"github.com/ajugalushkin/goph-keeper/client/internal/token"
 	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
 // keepCmd represents the keep command
@@ -19,6 +27,9 @@ func init() {
 	rootCmd.AddCommand(keepCmd)
 }
 
+// defaultBatchSize число секретов в одном пакетном запросе
+const defaultBatchSize = 100
+
 type Data struct {
 	Context []byte
 }
@@ -55,3 +66,160 @@ func decryptSecret(b []byte) (vaulttypes.Vault, error) {
 	}
 	return vaulttypes.DecodeVault(data.Context)
 }
+
+// cacheKeyName имя ключа шифрования локального кэша в хранилище ключей
+const cacheKeyName = "cache"
+
+// openCache открывает локальный кэш хранилища. Ключ шифрования кэша
+// хранится в том же хранилище ключей, что и ключ токенов.
+func openCache() (*cache.Cache, error) {
+	path, err := cache.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := token.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	key, err := token.Key(token.DefaultKeyring(dir), cacheKeyName, true)
+	if err != nil {
+		return nil, err
+	}
+	return cache.Open(path, key)
+}
+
+// listAllItems загружает все страницы списка секретов
+func listAllItems(
+	ctx context.Context,
+	keeperClient *app.KeeperClient,
+	req *v1.ListItemsRequestV1,
+) ([]*v1.SecretInfo, error) {
+	if req.GetPageSize() == 0 {
+		req.PageSize = 1000
+	}
+
+	secrets := make([]*v1.SecretInfo, 0)
+	for {
+		resp, err := keeperClient.ListItems(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, resp.GetSecrets()...)
+
+		req.PageToken = resp.GetNextPageToken()
+		if req.GetPageToken() == "" {
+			return secrets, nil
+		}
+	}
+}
+
+// syncCache загружает все секреты пользователя и обновляет локальный кэш.
+// Секреты расшифровываются только в памяти, в кэш записываются метаданные
+// и несекретные поля для поиска. Возвращает загруженные секреты вместе
+// с содержимым.
+func syncCache(ctx context.Context, keeperClient *app.KeeperClient, c *cache.Cache) ([]*v1.SecretInfo, error) {
+	secrets, err := listAllItems(ctx, keeperClient, &v1.ListItemsRequestV1{
+		IncludeContent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]cache.Item, 0, len(secrets))
+	for _, info := range secrets {
+		var fields map[string]string
+		secretType := info.GetType()
+		if secret, err := decryptSecret(info.GetContent()); err == nil {
+			fields = search.SearchableFields(secret)
+			secretType = string(secret.Type())
+		}
+
+		items = append(items, cache.Item{
+			ID:        info.GetItemId(),
+			Name:      info.GetName(),
+			Version:   info.GetVersion(),
+			Type:      secretType,
+			Folder:    info.GetFolder(),
+			Tags:      info.GetTags(),
+			CreatedAt: info.GetCreatedAt().AsTime(),
+			UpdatedAt: info.GetUpdatedAt().AsTime(),
+			Fields:    fields,
+		})
+	}
+
+	c.Replace(items)
+	return secrets, c.Save()
+}
+
+// createItems создает секреты пакетными запросами. Пакеты сохраняются
+// в режиме best effort: ошибка одного секрета не отменяет остальные.
+func createItems(
+	ctx context.Context,
+	keeperClient *app.KeeperClient,
+	requests []*v1.CreateItemRequestV1,
+	batchSize int,
+	log *slog.Logger,
+) (created int, failed int) {
+	for start := 0; start < len(requests); start += batchSize {
+		batch := requests[start:min(start+batchSize, len(requests))]
+
+		resp, err := keeperClient.BatchCreateItems(ctx, &v1.BatchCreateItemsRequestV1{
+			Items: batch,
+			Mode:  v1.BatchMode_BATCH_MODE_BEST_EFFORT,
+		})
+		if err != nil {
+			log.Error("Failed to create secrets: ", slog.String("error", err.Error()))
+			failed += len(batch)
+			continue
+		}
+
+		ok, errs := countBatchResults(resp.GetResults(), "Failed to create secret: ", log)
+		created += ok
+		failed += errs
+	}
+	return created, failed
+}
+
+// updateItems обновляет секреты пакетными запросами в режиме best effort
+func updateItems(
+	ctx context.Context,
+	keeperClient *app.KeeperClient,
+	requests []*v1.UpdateItemRequestV1,
+	batchSize int,
+	log *slog.Logger,
+) (updated int, failed int) {
+	for start := 0; start < len(requests); start += batchSize {
+		batch := requests[start:min(start+batchSize, len(requests))]
+
+		resp, err := keeperClient.BatchUpdateItems(ctx, &v1.BatchUpdateItemsRequestV1{
+			Items: batch,
+			Mode:  v1.BatchMode_BATCH_MODE_BEST_EFFORT,
+		})
+		if err != nil {
+			log.Error("Failed to update secrets: ", slog.String("error", err.Error()))
+			failed += len(batch)
+			continue
+		}
+
+		ok, errs := countBatchResults(resp.GetResults(), "Failed to update secret: ", log)
+		updated += ok
+		failed += errs
+	}
+	return updated, failed
+}
+
+// countBatchResults подсчитывает успешные элементы пакета и логирует ошибки
+func countBatchResults(results []*v1.BatchItemResultV1, msg string, log *slog.Logger) (ok int, failed int) {
+	for _, result := range results {
+		if codes.Code(result.GetCode()) != codes.OK {
+			log.Error(msg,
+				slog.String("name", result.GetName()),
+				slog.String("error", result.GetError()))
+			failed++
+			continue
+		}
+		ok++
+	}
+	return ok, failed
+}
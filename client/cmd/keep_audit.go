@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/audit"
+	"github.com/ajugalushkin/goph-keeper/client/internal/breach"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// keepAuditCmd represents the audit command
+var keepAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Report weak, reused and old passwords",
+	Long: `Check the passwords of all credentials secrets.
+Passwords are decrypted and checked locally, nothing is sent to the server.
+
+Each password gets a strength score from 0 (guessed instantly) to 4
+(very strong). Passwords used by several secrets and passwords that were
+not changed for longer than --max-age are reported too.
+
+Passwords are also checked against a local index of breached passwords
+if it exists, see "keep audit index". The check hashes passwords locally
+and never contacts external services.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_audit"
+		log := logger.GetInstance().Log.With("op", op)
+
+		format, err := auditFormat(cmd)
+		if err != nil {
+			log.Error("Error reading format flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if format == output.Env {
+			log.Error("Failed to print report: ", slog.String("error", output.ErrUnsupported{Format: format}.Error()))
+			return
+		}
+
+		minScore, err := cmd.Flags().GetInt("min-score")
+		if err != nil {
+			log.Error("Error reading min-score flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		maxAgeFlag, err := cmd.Flags().GetString("max-age")
+		if err != nil {
+			log.Error("Error reading max-age flag: ", slog.String("error", err.Error()))
+			return
+		}
+		maxAge, err := parseAge(maxAgeFlag)
+		if err != nil {
+			log.Error("Invalid max-age: ", slog.String("error", err.Error()))
+			return
+		}
+
+		breachIndex, err := openBreachIndex(cmd)
+		if err != nil {
+			log.Error("Failed to open breach index: ", slog.String("error", err.Error()))
+			return
+		}
+		if breachIndex != nil {
+			defer breachIndex.Close()
+		}
+
+		vaultCache, err := openCache()
+		if err != nil {
+			log.Error("Failed to open local cache: ", slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		// Пароли не хранятся в локальном кэше, поэтому аудит всегда
+		// загружает секреты с сервера и проверяет их в памяти
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		secrets, err := syncCache(context.Background(), keeperClient, vaultCache)
+		if secrets == nil && err != nil {
+			log.Error("Failed to load secrets: ", slog.String("error", err.Error()))
+			return
+		}
+		if err != nil {
+			log.Warn("Failed to update local cache: ", slog.String("error", err.Error()))
+		}
+
+		items := make([]audit.Item, 0, len(secrets))
+		for _, info := range secrets {
+			secret, err := decryptSecret(info.GetContent())
+			if err != nil {
+				log.Debug("Failed to decrypt secret: ",
+					slog.String("name", info.GetName()),
+					slog.String("error", err.Error()))
+				continue
+			}
+
+			credentials, ok := secret.(vaulttypes.Credentials)
+			if !ok {
+				continue
+			}
+			items = append(items, audit.Item{
+				Name:      info.GetName(),
+				Login:     credentials.Login,
+				Password:  credentials.Password,
+				URL:       credentials.URL,
+				UpdatedAt: info.GetUpdatedAt().AsTime(),
+			})
+		}
+
+		opts := audit.Options{
+			MinScore: minScore,
+			MaxAge:   maxAge,
+		}
+		if breachIndex != nil {
+			opts.Breached = breachIndex.Lookup
+		}
+
+		report, err := audit.Run(items, opts)
+		if err != nil {
+			log.Error("Failed to audit secrets: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if format == output.Table {
+			err = printAuditReport(report)
+		} else {
+			err = output.Write(os.Stdout, format, report)
+		}
+		if err != nil {
+			log.Error("Failed to print report: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+// auditFormat возвращает формат отчета: флаг --format сохранен
+// для совместимости и имеет приоритет над --output
+func auditFormat(cmd *cobra.Command) (output.Format, error) {
+	if !cmd.Flags().Changed("format") {
+		return outputFormat(cmd)
+	}
+	value, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	return output.Parse(value)
+}
+
+func init() {
+	keepCmd.AddCommand(keepAuditCmd)
+
+	keepAuditCmd.Flags().String("format", "table", "Report format: table, json, yaml, overrides --output")
+	keepAuditCmd.Flags().Int("min-score", 3, "Minimum acceptable strength score, 0-4")
+	keepAuditCmd.Flags().String("max-age", "365d", "Report passwords older than this, e.g. 90d or 2160h, 0 to disable")
+	keepAuditCmd.Flags().String("breach-index", "", "Breached passwords index, defaults to the index built by \"keep audit index\"")
+}
+
+// openBreachIndex открывает индекс утекших паролей. Индекс по умолчанию
+// необязателен: если его нет, проверка по утечкам пропускается.
+func openBreachIndex(cmd *cobra.Command) (*breach.Index, error) {
+	path, err := cmd.Flags().GetString("breach-index")
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" {
+		if path, err = breach.DefaultPath(); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+	}
+	return breach.Open(path)
+}
+
+func printAuditReport(report audit.Report) error {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tSCORE\tAGE\tISSUES")
+	for _, item := range report.Items {
+		issues := make([]string, 0, 3)
+		if item.Weak {
+			issue := "weak"
+			if item.Strength.Warning != "" {
+				issue += ": " + item.Strength.Warning
+			}
+			issues = append(issues, issue)
+		}
+		if len(item.ReusedWith) > 0 {
+			issues = append(issues, "reused with "+strings.Join(item.ReusedWith, ", "))
+		}
+		if item.Old {
+			issues = append(issues, "not rotated")
+		}
+		if item.Breached > 0 {
+			issues = append(issues, fmt.Sprintf("found in breaches %d times", item.Breached))
+		}
+		if len(issues) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(writer, "%s\t%d/4\t%dd\t%s\n",
+			item.Name, item.Strength.Score, item.AgeDays, strings.Join(issues, "; "))
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d credentials checked: %d weak, %d reused, %d old",
+		report.Summary.Total, report.Summary.Weak, report.Summary.Reused, report.Summary.Old)
+	if report.Summary.Breached >= 0 {
+		fmt.Printf(", %d breached", report.Summary.Breached)
+	}
+	fmt.Println()
+	return nil
+}
+
+// parseAge разбирает длительность, дополнительно допуская дни: 90d
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/breach"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+)
+
+// keepAuditIndexCmd represents the audit index command
+var keepAuditIndexCmd = &cobra.Command{
+	Use:   "index <dataset>",
+	Short: "Build breached passwords index",
+	Long: `Build a compact local index from a breached passwords dataset.
+The dataset is a text file in the HIBP Pwned Passwords format: SHA-1
+hashes with counts, one "HASH:COUNT" per line, sorted by hash.
+The index is used by "keep audit" to check passwords offline.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_audit_index"
+		log := logger.GetInstance().Log.With("op", op)
+
+		output, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Error("Error reading file flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if output == "" {
+			if output, err = breach.DefaultPath(); err != nil {
+				log.Error("Failed to get index path: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		dataset, err := os.Open(args[0])
+		if err != nil {
+			log.Error("Failed to open dataset: ", slog.String("error", err.Error()))
+			return
+		}
+		defer dataset.Close()
+
+		if err := os.MkdirAll(filepath.Dir(output), 0o700); err != nil {
+			log.Error("Failed to create index directory: ", slog.String("error", err.Error()))
+			return
+		}
+
+		// Индекс строится во временном файле и заменяет старый только целиком.
+		index, err := os.CreateTemp(filepath.Dir(output), filepath.Base(output)+".*")
+		if err != nil {
+			log.Error("Failed to create index: ", slog.String("error", err.Error()))
+			return
+		}
+		defer os.Remove(index.Name())
+
+		count, err := breach.Build(dataset, index)
+		if closeErr := index.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error("Failed to build index: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := os.Rename(index.Name(), output); err != nil {
+			log.Error("Failed to save index: ", slog.String("error", err.Error()))
+			return
+		}
+
+		fmt.Printf("Indexed %d password hashes to %s\n", count, output)
+	},
+}
+
+func init() {
+	keepAuditCmd.AddCommand(keepAuditIndexCmd)
+
+	keepAuditIndexCmd.Flags().StringP("file", "f", "", "Index file path, defaults to the user cache directory")
+}
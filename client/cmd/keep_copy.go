@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/clipboard"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// defaultClipboardClear время до очистки буфера обмена по умолчанию
+const defaultClipboardClear = 45 * time.Second
+
+// keepCopyCmd represents the copy command
+var keepCopyCmd = &cobra.Command{
+	Use:   "copy <name>",
+	Short: "Copy secret field to clipboard",
+	Long: `Copy a field of a secret to the clipboard instead of printing it,
+so the value does not end up in terminal scrollback or shell logs.
+Without --field the first sensitive field is copied, e.g. the password.
+
+The clipboard is cleared after --clear (clipboard.clear in the config,
+45s by default) unless its content was changed in the meantime. The
+command waits until then, Ctrl+C clears the clipboard at once.
+Use --clear 0 (or clipboard.clear: 0) to keep the value.
+
+--backend (clipboard.backend in the config) selects the clipboard:
+system uses wl-copy, xclip, xsel, pbcopy or clip.exe, osc52 asks the
+terminal to set the clipboard and works over SSH, auto picks osc52 in
+SSH sessions without a display and system otherwise.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSecretArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_copy"
+		log := logger.GetInstance().Log.With("op", op)
+
+		field, err := cmd.Flags().GetString("field")
+		if err != nil {
+			log.Error("Error reading field flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		cfg := config.GetInstance().Config.Clipboard
+		clearAfter := cfg.Clear
+		// clipboard.clear: 0 в конфигурации отключает очистку, поэтому
+		// значение флага берется, только если ключ не задан
+		if !viper.IsSet("clipboard.clear") || cmd.Flags().Changed("clear") {
+			if clearAfter, err = cmd.Flags().GetDuration("clear"); err != nil {
+				log.Error("Error reading clear flag: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		backend := cfg.Backend
+		if backend == "" || cmd.Flags().Changed("backend") {
+			if backend, err = cmd.Flags().GetString("backend"); err != nil {
+				log.Error("Error reading backend flag: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		board, err := clipboard.New(clipboard.Backend(backend))
+		if err != nil {
+			log.Error("Clipboard unavailable: ", slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		resp, err := keeperClient.GetItem(context.Background(), &v1.GetItemRequestV1{
+			Name: args[0],
+		})
+		if err != nil {
+			log.Error("Failed to get secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		secret, err := decryptSecret(resp.GetContent())
+		if err != nil {
+			log.Error("Failed to decrypt secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if field == "" {
+			if field, err = copyField(secret); err != nil {
+				log.Error("Failed to copy secret: ", slog.String("error", err.Error()))
+				return
+			}
+		} else if output.IsBinary(secret, field) {
+			log.Error("Binary fields can not be copied, use keep get --file")
+			return
+		}
+
+		value, err := output.Binary(secret, field)
+		if err != nil {
+			log.Error("Failed to copy secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		text := string(value)
+		if err := board.Copy(text); err != nil {
+			log.Error("Failed to copy to clipboard: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if clearAfter <= 0 {
+			fmt.Fprintf(os.Stderr, "Copied %s of %s to clipboard\n", field, resp.GetName())
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Copied %s of %s to clipboard, clearing in %s\n",
+			field, resp.GetName(), clearAfter)
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		cleared, err := clipboard.ClearAfter(ctx, board, text, clearAfter)
+		if err != nil {
+			log.Error("Failed to clear clipboard: ", slog.String("error", err.Error()))
+			return
+		}
+		if cleared {
+			fmt.Fprintln(os.Stderr, "Clipboard cleared")
+		} else {
+			fmt.Fprintln(os.Stderr, "Clipboard changed, not cleared")
+		}
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepCopyCmd)
+
+	keepCopyCmd.Flags().String("field", "", "Field to copy, the first sensitive field by default")
+	keepCopyCmd.Flags().Duration("clear", defaultClipboardClear, "Clear the clipboard after this time, 0 to disable")
+	keepCopyCmd.Flags().String("backend", string(clipboard.Auto), "Clipboard backend: auto, system, osc52")
+}
+
+// copyField выбирает поле для копирования: первое секретное поле,
+// а если таких нет, первое текстовое поле типа
+func copyField(secret vaulttypes.Vault) (string, error) {
+	schema, ok := vaulttypes.Lookup(secret.Type())
+	if !ok {
+		return "", fmt.Errorf("unknown secret type %s", secret.Type())
+	}
+
+	first := ""
+	for _, field := range schema.Fields {
+		if field.Binary {
+			continue
+		}
+		if field.Sensitive {
+			return field.Name, nil
+		}
+		if first == "" {
+			first = field.Name
+		}
+	}
+	if first == "" {
+		return "", fmt.Errorf("secret of type %s has no fields to copy, use keep get --file", secret.Type())
+	}
+	return first, nil
+}
@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
 // keepCreateCmd represents the create command
@@ -12,4 +15,49 @@ var keepCreateCmd = &cobra.Command{
 
 func init() {
 	keepCmd.AddCommand(keepCreateCmd)
+
+	keepCreateCmd.PersistentFlags().String("folder", "", "Secret folder")
+	registerFlagCompletion(keepCreateCmd, "folder", completeFolders)
+	keepCreateCmd.PersistentFlags().StringSlice("tag", nil, "Secret tags")
+}
+
+// newCreateItemRequest шифрует секрет и дополняет запрос метаданными,
+// по которым сервер фильтрует список секретов.
+func newCreateItemRequest(
+	cmd *cobra.Command,
+	name string,
+	secret vaulttypes.Vault,
+) (*v1.CreateItemRequestV1, error) {
+	folder, err := cmd.Flags().GetString("folder")
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := cmd.Flags().GetStringSlice("tag")
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCreateItemRequest(name, folder, tags, secret)
+}
+
+// buildCreateItemRequest шифрует секрет и собирает запрос на его создание
+func buildCreateItemRequest(
+	name string,
+	folder string,
+	tags []string,
+	secret vaulttypes.Vault,
+) (*v1.CreateItemRequestV1, error) {
+	content, err := encryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.CreateItemRequestV1{
+		Name:    name,
+		Content: content,
+		Type:    string(secret.Type()),
+		Folder:  folder,
+		Tags:    tags,
+	}, nil
 }
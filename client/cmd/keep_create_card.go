@@ -10,7 +10,6 @@ import (
 	"github.com/ajugalushkin/goph-keeper/client/internal/app"
 	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
 	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
-	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
 // keepCreateCardCmd represents the card command
@@ -28,7 +27,7 @@ var keepCreateCardCmd = &cobra.Command{
 			return
 		}
 
-		number, err := cmd.Flags().GetString("number")
+		number, err := readSecretFlag(cmd, "number", "Card number", false)
 		if err != nil {
 			log.Error("Error reading card number: ",
 				slog.String("error", err.Error()))
@@ -42,7 +41,7 @@ var keepCreateCardCmd = &cobra.Command{
 			return
 		}
 
-		code, err := cmd.Flags().GetString("code")
+		code, err := readSecretFlag(cmd, "code", "Card security code", false)
 		if err != nil {
 			log.Error("Error reading card security code: ",
 				slog.String("error", err.Error()))
@@ -56,35 +55,49 @@ var keepCreateCardCmd = &cobra.Command{
 			return
 		}
 
+		notes, err := cmd.Flags().GetString("notes")
+		if err != nil {
+			log.Error("Error reading card notes: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
 		card := vaulttypes.Card{
 			Number:       number,
 			ExpiryDate:   date,
 			SecurityCode: code,
 			Holder:       holder,
+			Notes:        notes,
 		}
 
-		content, err := encryptSecret(card)
+		req, err := newCreateItemRequest(cmd, name, card)
 		if err != nil {
-			log.Error("Failed to encrypt secret: ",
+			log.Error("Failed to prepare secret: ",
 				slog.String("error", err.Error()))
 			return
 		}
 
 		token, err := tokenStorage.Load()
 		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
 			return
 		}
-		keeperClient := app.NewKeeperClient(app.GetKeeperConnection(token))
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
 
-		resp, err := keeperClient.CreateItem(context.Background(), &v1.CreateItemRequestV1{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := keeperClient.CreateItem(context.Background(), req)
 		if err != nil {
 			log.Error("Failed to create secret: ", slog.String("error", err.Error()))
 		}
 
-		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+		if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), resp.GetItemId(),
+			fmt.Sprintf("Secret %s version %v created successfully", resp.GetName(), resp.GetVersion())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
 	},
 }
 
@@ -98,28 +111,19 @@ func init() {
 			slog.String("op", op),
 			slog.String("error", err.Error()))
 	}
-	keepCreateCardCmd.Flags().String("number", "", "Card number")
-	if err := keepCreateCardCmd.MarkFlagRequired("number"); err != nil {
-		slog.Error("Error setting flag: ",
-			slog.String("op", op),
-			slog.String("error", err.Error()))
-	}
+	addSecretFlag(keepCreateCardCmd, "number", "", "Card number", true)
 	keepCreateCardCmd.Flags().String("date", "", "Card expiry date")
 	if err := keepCreateCardCmd.MarkFlagRequired("date"); err != nil {
 		slog.Error("Error setting flag: ",
 			slog.String("op", op),
 			slog.String("error", err.Error()))
 	}
-	keepCreateCardCmd.Flags().String("code", "", "Card security code")
-	if err := keepCreateCardCmd.MarkFlagRequired("code"); err != nil {
-		slog.Error("Error setting flag: ",
-			slog.String("op", op),
-			slog.String("error", err.Error()))
-	}
+	addSecretFlag(keepCreateCardCmd, "code", "", "Card security code", true)
 	keepCreateCardCmd.Flags().String("holder", "", "Card holder")
 	if err := keepCreateCardCmd.MarkFlagRequired("holder"); err != nil {
 		slog.Error("Error setting flag: ",
 			slog.String("op", op),
 			slog.String("error", err.Error()))
 	}
+	keepCreateCardCmd.Flags().String("notes", "", "Card notes")
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/ajugalushkin/goph-keeper/client/internal/app"
 	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
 	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
-	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
 // keepCreateCredentialsCmd represents the credentials command
@@ -33,38 +32,81 @@ var keepCreateCredentialsCmd = &cobra.Command{
 			return
 		}
 
-		password, err := cmd.Flags().GetString("password")
+		generate, err := cmd.Flags().GetBool("generate")
 		if err != nil {
+			log.Error("Unable to get `generate` arg: ", slog.String("error", err.Error()))
+			return
+		}
+		if generate && secretFlagSet(cmd, "password") {
+			log.Error("--generate can not be combined with --password")
+			return
+		}
+
+		var (
+			password string
+			entropy  float64
+		)
+		if generate {
+			password, entropy, err = generateSecret(cmd.Flags())
+			if err != nil {
+				log.Error("Failed to generate password: ", slog.String("error", err.Error()))
+				return
+			}
+		} else if password, err = readSecretFlag(cmd, "password", "Password", false); err != nil {
 			log.Error("Unable to get `password` arg: ", slog.String("error", err.Error()))
 			return
 		}
 
+		url, err := cmd.Flags().GetString("url")
+		if err != nil {
+			log.Error("Unable to get `url` arg: ", slog.String("error", err.Error()))
+			return
+		}
+
+		notes, err := cmd.Flags().GetString("notes")
+		if err != nil {
+			log.Error("Unable to get `notes` arg: ", slog.String("error", err.Error()))
+			return
+		}
+
 		credentials := vaulttypes.Credentials{
 			Login:    login,
 			Password: password,
+			URL:      url,
+			Notes:    notes,
 		}
 
-		content, err := encryptSecret(credentials)
+		req, err := newCreateItemRequest(cmd, name, credentials)
 		if err != nil {
-			log.Error("Failed to encrypt secret: ", slog.String("error", err.Error()))
+			log.Error("Failed to prepare secret: ", slog.String("error", err.Error()))
 			return
 		}
 
 		token, err := tokenStorage.Load()
 		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
 			return
 		}
-		keeperClient := app.NewKeeperClient(app.GetKeeperConnection(token))
+		keeperClient := app.NewKeeperClient(conn)
 
-		resp, err := keeperClient.CreateItem(context.Background(), &v1.CreateItemRequestV1{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := keeperClient.CreateItem(context.Background(), req)
 		if err != nil {
 			log.Error("Failed to create secret: ", slog.String("error", err.Error()))
+			return
 		}
 
-		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+		if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), resp.GetItemId(),
+			fmt.Sprintf("Secret %s version %v created successfully", resp.GetName(), resp.GetVersion())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
+		if generate {
+			printEntropy(entropy)
+		}
 	},
 }
 
@@ -79,8 +121,9 @@ func init() {
 	if err := keepCreateCredentialsCmd.MarkFlagRequired("login"); err != nil {
 		slog.Error("Unable to mark 'login' flag as required %s", slog.String("error", err.Error()))
 	}
-	keepCreateCredentialsCmd.Flags().String("password", "", "Password")
-	if err := keepCreateCredentialsCmd.MarkFlagRequired("password"); err != nil {
-		slog.Error("Unable to mark 'password' flag as required %s", slog.String("error", err.Error()))
-	}
+	addSecretFlag(keepCreateCredentialsCmd, "password", "", "Password", true)
+	keepCreateCredentialsCmd.Flags().Bool("generate", false, "Generate the password")
+	keepCreateCredentialsCmd.Flags().String("url", "", "Site URL")
+	keepCreateCredentialsCmd.Flags().String("notes", "", "Notes")
+	addGeneratorFlags(keepCreateCredentialsCmd.Flags())
 }
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/otp"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// keepCreateOTPCmd represents the otp command
+var keepCreateOTPCmd = &cobra.Command{
+	Use:   "otp",
+	Short: "Create one-time password secret",
+	Long: `Create TOTP or HOTP authenticator secret.
+The secret is taken from an otpauth:// URI, otherwise it is prompted
+for or read with --secret-stdin or --secret-fd.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_create_otp"
+		log := logger.GetInstance().Log.With("op", op)
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Error("Error reading secret name: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		secret, err := otpFromFlags(cmd)
+		if err != nil {
+			log.Error("Invalid one-time password secret: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		req, err := newCreateItemRequest(cmd, name, secret)
+		if err != nil {
+			log.Error("Failed to prepare secret: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		resp, err := keeperClient.CreateItem(context.Background(), req)
+		if err != nil {
+			log.Error("Failed to create secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), resp.GetItemId(),
+			fmt.Sprintf("Secret %s version %v created successfully", resp.GetName(), resp.GetVersion())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	const op = "keep_create_otp"
+	keepCreateCmd.AddCommand(keepCreateOTPCmd)
+
+	keepCreateOTPCmd.Flags().String("name", "", "Secret name")
+	if err := keepCreateOTPCmd.MarkFlagRequired("name"); err != nil {
+		slog.Error("Error setting flag: ",
+			slog.String("op", op),
+			slog.String("error", err.Error()))
+	}
+	keepCreateOTPCmd.Flags().String("uri", "", "otpauth:// URI")
+	addSecretFlag(keepCreateOTPCmd, "secret", "", "Base32 shared secret", true)
+	keepCreateOTPCmd.Flags().String("kind", otp.KindTOTP, "OTP kind: totp or hotp")
+	keepCreateOTPCmd.Flags().String("issuer", "", "Issuer")
+	keepCreateOTPCmd.Flags().String("account", "", "Account name")
+	keepCreateOTPCmd.Flags().String("algorithm", "SHA1", "Hash algorithm: SHA1, SHA256 or SHA512")
+	keepCreateOTPCmd.Flags().Int("digits", 6, "Number of code digits")
+	keepCreateOTPCmd.Flags().Int("period", 30, "TOTP period in seconds")
+	keepCreateOTPCmd.Flags().Uint64("counter", 0, "HOTP counter")
+	keepCreateOTPCmd.MarkFlagsMutuallyExclusive("uri", "secret")
+}
+
+// otpFromFlags собирает OTP секрет из URI или отдельных флагов
+func otpFromFlags(cmd *cobra.Command) (vaulttypes.OTP, error) {
+	flags := cmd.Flags()
+
+	uri, err := flags.GetString("uri")
+	if err != nil {
+		return vaulttypes.OTP{}, err
+	}
+	if uri != "" {
+		return otp.ParseURI(uri)
+	}
+
+	var secret vaulttypes.OTP
+	if secret.Secret, err = readSecretFlag(cmd, "secret", "Base32 shared secret", false); err != nil {
+		return vaulttypes.OTP{}, err
+	}
+	for flag, value := range map[string]*string{
+		"kind":      &secret.Kind,
+		"issuer":    &secret.Issuer,
+		"account":   &secret.Account,
+		"algorithm": &secret.Algorithm,
+	} {
+		if *value, err = flags.GetString(flag); err != nil {
+			return vaulttypes.OTP{}, err
+		}
+	}
+	if secret.Digits, err = flags.GetInt("digits"); err != nil {
+		return vaulttypes.OTP{}, err
+	}
+	if secret.Period, err = flags.GetInt("period"); err != nil {
+		return vaulttypes.OTP{}, err
+	}
+	if secret.Counter, err = flags.GetUint64("counter"); err != nil {
+		return vaulttypes.OTP{}, err
+	}
+	if secret.Kind == otp.KindHOTP {
+		secret.Period = 0
+	}
+
+	secret = otp.Normalize(secret)
+	return secret, otp.Validate(secret)
+}
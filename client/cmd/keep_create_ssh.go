@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/sshkey"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// keepCreateSSHCmd represents the ssh command
+var keepCreateSSHCmd = &cobra.Command{
+	Use:   "ssh",
+	Short: "Create SSH key secret",
+	Long: `Create SSH key secret.
+The key is either generated (--generate ed25519|rsa|ecdsa) or imported from a private key file (--file).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_create_ssh"
+		log := logger.GetInstance().Log.With("op", op)
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Error("Error reading secret name: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		generate, err := cmd.Flags().GetString("generate")
+		if err != nil {
+			log.Error("Error reading key type: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		bits, err := cmd.Flags().GetInt("bits")
+		if err != nil {
+			log.Error("Error reading key size: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Error("Error reading key file path: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		comment, err := cmd.Flags().GetString("comment")
+		if err != nil {
+			log.Error("Error reading key comment: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		var passphrase string
+		if secretFlagSet(cmd, "passphrase") {
+			if passphrase, err = readSecretFlag(cmd, "passphrase", "Private key passphrase", false); err != nil {
+				log.Error("Error reading key passphrase: ",
+					slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		var key vaulttypes.SSHKey
+		if generate != "" {
+			key, err = sshkey.Generate(generate, bits, comment, passphrase)
+		} else {
+			key, err = sshkey.Import(file, comment, passphrase)
+		}
+		if err != nil {
+			log.Error("Failed to prepare SSH key: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		req, err := newCreateItemRequest(cmd, name, key)
+		if err != nil {
+			log.Error("Failed to prepare secret: ",
+				slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		resp, err := keeperClient.CreateItem(context.Background(), req)
+		if err != nil {
+			log.Error("Failed to create secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+		result := output.Object{
+			{Key: "name", Value: resp.GetName()},
+			{Key: "version", Value: resp.GetVersion()},
+			{Key: "item_id", Value: resp.GetItemId()},
+			{Key: "public_key", Value: key.PublicKey},
+		}
+		message := fmt.Sprintf("Secret %s version %v created successfully\n%s", resp.GetName(), resp.GetVersion(), key.PublicKey)
+		if err := printResult(format, result, message); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	const op = "keep_create_ssh"
+	keepCreateCmd.AddCommand(keepCreateSSHCmd)
+
+	keepCreateSSHCmd.Flags().String("name", "", "Secret name")
+	if err := keepCreateSSHCmd.MarkFlagRequired("name"); err != nil {
+		slog.Error("Error setting flag: ",
+			slog.String("op", op),
+			slog.String("error", err.Error()))
+	}
+	keepCreateSSHCmd.Flags().String("generate", "", "Generate key of type: ed25519, rsa, ecdsa")
+	keepCreateSSHCmd.Flags().Int("bits", 0, "Key size for rsa and ecdsa keys")
+	keepCreateSSHCmd.Flags().StringP("file", "f", "", "Private key file to import")
+	keepCreateSSHCmd.Flags().String("comment", "", "Key comment")
+	addSecretFlag(keepCreateSSHCmd, "passphrase", "", "Private key passphrase", false)
+	keepCreateSSHCmd.MarkFlagsOneRequired("generate", "file")
+	keepCreateSSHCmd.MarkFlagsMutuallyExclusive("generate", "file")
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// reservedCreateFlags флаги команды создания, которые не могут быть полями шаблона
+var reservedCreateFlags = map[string]bool{
+	"name":    true,
+	"folder":  true,
+	"tag":     true,
+	"config":  true,
+	"help":    true,
+	"output":  true,
+	"field":   true,
+	"profile": true,
+}
+
+// registerTemplates регистрирует пользовательские типы секретов из конфигурации
+// и создает команды keep create для всех типов, у которых нет собственной команды.
+func registerTemplates() {
+	const op = "register_templates"
+
+templates:
+	for _, template := range config.GetInstance().Config.Templates {
+		fields := make([]vaulttypes.TemplateField, 0, len(template.Fields))
+		seen := make(map[string]bool, len(template.Fields))
+		for _, field := range template.Fields {
+			if reason := templateFieldError(field.Name, seen); reason != "" {
+				slog.Error("Secret template field name is not allowed: ",
+					slog.String("op", op),
+					slog.String("type", template.Type),
+					slog.String("field", field.Name),
+					slog.String("reason", reason))
+				continue templates
+			}
+			seen[field.Name] = true
+			fields = append(fields, vaulttypes.TemplateField{
+				Name:        field.Name,
+				Sensitive:   field.Sensitive,
+				Required:    field.Required,
+				Description: field.Description,
+			})
+		}
+
+		if err := vaulttypes.RegisterTemplate(template.Type, fields); err != nil {
+			slog.Error("Error registering secret template: ",
+				slog.String("op", op),
+				slog.String("type", template.Type),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	existing := make(map[string]bool)
+	for _, command := range keepCreateCmd.Commands() {
+		existing[command.Name()] = true
+	}
+
+	for _, schema := range vaulttypes.Schemas() {
+		if existing[string(schema.Type)] {
+			continue
+		}
+		keepCreateCmd.AddCommand(newCreateSchemaCmd(schema))
+	}
+}
+
+// templateFieldError проверяет, что поле шаблона можно зарегистрировать
+// флагом, не задев другие флаги команды. Суффиксы -stdin и -fd заняты
+// вариантами секретных флагов, см. addSecretFlag.
+func templateFieldError(name string, seen map[string]bool) string {
+	switch {
+	case reservedCreateFlags[name]:
+		return "name is reserved"
+	case strings.HasSuffix(name, "-stdin"), strings.HasSuffix(name, "-fd"):
+		return "suffixes -stdin and -fd are reserved"
+	case seen[name]:
+		return "duplicate field name"
+	}
+	return ""
+}
+
+// newCreateSchemaCmd создает команду создания секрета, флаги которой
+// соответствуют полям схемы типа.
+func newCreateSchemaCmd(schema vaulttypes.Schema) *cobra.Command {
+	op := "keep_create_" + string(schema.Type)
+
+	cmd := &cobra.Command{
+		Use:   string(schema.Type),
+		Short: fmt.Sprintf("Create %s secret", schema.Type),
+		Run: func(cmd *cobra.Command, args []string) {
+			log := logger.GetInstance().Log.With("op", op)
+
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				log.Error("Error reading secret name: ",
+					slog.String("error", err.Error()))
+				return
+			}
+
+			values := make(map[string]string, len(schema.Fields))
+			for _, field := range schema.Fields {
+				var value string
+				switch {
+				case !secretField(field):
+					value, err = cmd.Flags().GetString(field.Name)
+				case field.Required || secretFlagSet(cmd, field.Name):
+					value, err = readSecretFlag(cmd, field.Name, field.Usage, false)
+				}
+				if err != nil {
+					log.Error("Error reading secret field: ",
+						slog.String("field", field.Name),
+						slog.String("error", err.Error()))
+					return
+				}
+				if value != "" {
+					values[field.Name] = value
+				}
+			}
+
+			secret, err := vaulttypes.FromValues(schema.Type, values)
+			if err != nil {
+				log.Error("Invalid secret: ", slog.String("error", err.Error()))
+				return
+			}
+
+			req, err := newCreateItemRequest(cmd, name, secret)
+			if err != nil {
+				log.Error("Failed to prepare secret: ",
+					slog.String("error", err.Error()))
+				return
+			}
+
+			token, err := tokenStorage.Load()
+			if err != nil {
+				log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+				return
+			}
+			conn, err := app.GetKeeperConnection(token)
+			if err != nil {
+				log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+				return
+			}
+			keeperClient := app.NewKeeperClient(conn)
+
+			resp, err := keeperClient.CreateItem(context.Background(), req)
+			if err != nil {
+				log.Error("Failed to create secret: ", slog.String("error", err.Error()))
+				return
+			}
+
+			if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), resp.GetItemId(),
+				fmt.Sprintf("Secret %s version %v created successfully", resp.GetName(), resp.GetVersion())); err != nil {
+				log.Error("Failed to print result: ", slog.String("error", err.Error()))
+			}
+		},
+	}
+
+	cmd.Flags().String("name", "", "Secret name")
+	if err := cmd.MarkFlagRequired("name"); err != nil {
+		slog.Error("Error setting flag: ",
+			slog.String("op", op),
+			slog.String("error", err.Error()))
+	}
+
+	for _, field := range schema.Fields {
+		if secretField(field) {
+			addSecretFlag(cmd, field.Name, "", field.Usage, true)
+			continue
+		}
+		cmd.Flags().String(field.Name, "", field.Usage)
+		if !field.Required {
+			continue
+		}
+		if err := cmd.MarkFlagRequired(field.Name); err != nil {
+			slog.Error("Error setting flag: ",
+				slog.String("op", op),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return cmd
+}
+
+// secretField проверяет, что значение поля запрашивается скрытым вводом
+func secretField(field vaulttypes.Field) bool {
+	return field.Sensitive && !field.Binary
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/ajugalushkin/goph-keeper/client/internal/app"
 	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
 	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
-	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
 // keepCreateTextCmd represents the text command
@@ -39,28 +38,34 @@ var keepCreateTextCmd = &cobra.Command{
 			Data: data,
 		}
 
-		content, err := encryptSecret(text)
+		req, err := newCreateItemRequest(cmd, name, text)
 		if err != nil {
-			log.Error("Failed to encrypt secret: ",
+			log.Error("Failed to prepare secret: ",
 				slog.String("error", err.Error()))
 			return
 		}
 
 		token, err := tokenStorage.Load()
 		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
 			return
 		}
-		keeperClient := app.NewKeeperClient(app.GetKeeperConnection(token))
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
 
-		resp, err := keeperClient.CreateItem(context.Background(), &v1.CreateItemRequestV1{
-			Name:    name,
-			Content: content,
-		})
+		resp, err := keeperClient.CreateItem(context.Background(), req)
 		if err != nil {
 			log.Error("Failed to create secret: ", slog.String("error", err.Error()))
 		}
 
-		fmt.Printf("Secret %s version %v created successfully\n", resp.GetName(), resp.GetVersion())
+		if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), resp.GetItemId(),
+			fmt.Sprintf("Secret %s version %v created successfully", resp.GetName(), resp.GetVersion())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
 	},
 }
 
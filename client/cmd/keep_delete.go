@@ -1,20 +1,86 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
 // deleteCmd represents the delete command
 var keepDeleteCmd = &cobra.Command{
-	Use:   "delete",
-	Short: "A brief description of your command",
+	Use:   "delete <name>",
+	Short: "Delete secret",
+	Long: `Delete a secret by name. With --id the argument is the secret id.
+With --version the secret is deleted only if it was not modified since
+that version was read.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSecretArg,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("delete called")
+		const op = "keep_delete"
+		log := logger.GetInstance().Log.With("op", op)
+
+		byID, err := cmd.Flags().GetBool("id")
+		if err != nil {
+			log.Error("Error reading id flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		version, err := cmd.Flags().GetString("version")
+		if err != nil {
+			log.Error("Error reading version flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		req := &v1.DeleteItemRequestV1{Version: version}
+		if byID {
+			req.ItemId = args[0]
+		} else {
+			req.Name = args[0]
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		resp, err := keeperClient.DeleteItem(context.Background(), req)
+		if err != nil {
+			log.Error("Failed to delete secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+		result := output.Object{
+			{Key: "name", Value: resp.GetName()},
+			{Key: "item_id", Value: resp.GetItemId()},
+		}
+		if err := printResult(format, result, fmt.Sprintf("Secret %s deleted", resp.GetName())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
 	},
 }
 
 func init() {
 	keepCmd.AddCommand(keepDeleteCmd)
+
+	keepDeleteCmd.Flags().Bool("id", false, "Treat the argument as a secret id")
+	keepDeleteCmd.Flags().String("version", "", "Delete only if the secret has this version")
 }
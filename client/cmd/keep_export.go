@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/archive"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// keepExportCmd represents the export command
+var keepExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export all secrets to an encrypted archive",
+	Long: `Export every secret with its metadata and binary data to a single archive
+encrypted with a password. The archive can be loaded into the same or
+another account with "keep restore".
+
+With --plaintext the archive is written as unencrypted JSON. Anyone who can
+read that file can read all of your secrets.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_export"
+		log := logger.GetInstance().Log.With("op", op)
+
+		output, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Error("Error reading file flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		plaintext, err := cmd.Flags().GetBool("plaintext")
+		if err != nil {
+			log.Error("Error reading plaintext flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if plaintext && secretFlagSet(cmd, "password") {
+			log.Error("--plaintext can not be combined with --password")
+			return
+		}
+
+		var password string
+		if !plaintext {
+			if password, err = readSecretFlag(cmd, "password", "Archive password", true); err != nil {
+				log.Error("Error reading password flag: ", slog.String("error", err.Error()))
+				return
+			}
+			if password == "" {
+				log.Error("Error reading password flag: ", slog.String("error", archive.ErrEmptyPassword.Error()))
+				return
+			}
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		secrets, err := listAllItems(context.Background(), keeperClient, &v1.ListItemsRequestV1{
+			IncludeContent: true,
+		})
+		if err != nil {
+			log.Error("Failed to list secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		items := make([]archive.Item, 0, len(secrets))
+		for _, info := range secrets {
+			item, err := newArchiveItem(info)
+			if err != nil {
+				log.Error("Failed to export secret: ",
+					slog.String("name", info.GetName()),
+					slog.String("error", err.Error()))
+				return
+			}
+			items = append(items, item)
+		}
+
+		var data []byte
+		if plaintext {
+			data, err = archive.MarshalPlain(archive.New(items))
+		} else {
+			data, err = archive.Encrypt(archive.New(items), []byte(password))
+		}
+		if err != nil {
+			log.Error("Failed to create archive: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := os.WriteFile(output, data, 0o600); err != nil {
+			log.Error("Failed to write archive: ", slog.String("error", err.Error()))
+			return
+		}
+
+		fmt.Printf("Exported %d secrets to %s\n", len(items), output)
+	},
+}
+
+func init() {
+	const op = "keep_export"
+
+	keepCmd.AddCommand(keepExportCmd)
+
+	keepExportCmd.Flags().StringP("file", "f", "", "Archive file path")
+	if err := keepExportCmd.MarkFlagRequired("file"); err != nil {
+		slog.Error("Error setting flag: ",
+			slog.String("op", op),
+			slog.String("error", err.Error()))
+	}
+
+	addSecretFlag(keepExportCmd, "password", "", "Archive password", true)
+	keepExportCmd.Flags().Bool("plaintext", false, "Write an unencrypted JSON archive")
+	keepExportCmd.MarkFlagsMutuallyExclusive("password", "plaintext")
+}
+
+// newArchiveItem расшифровывает секрет и переводит его в формат архива
+func newArchiveItem(info *v1.SecretInfo) (archive.Item, error) {
+	secret, err := decryptSecret(info.GetContent())
+	if err != nil {
+		return archive.Item{}, err
+	}
+
+	encoded, err := vaulttypes.EncodeVault(secret)
+	if err != nil {
+		return archive.Item{}, err
+	}
+
+	return archive.Item{
+		Name:      info.GetName(),
+		Type:      string(secret.Type()),
+		Folder:    info.GetFolder(),
+		Tags:      info.GetTags(),
+		CreatedAt: info.GetCreatedAt().AsTime(),
+		UpdatedAt: info.GetUpdatedAt().AsTime(),
+		Secret:    encoded,
+	}, nil
+}
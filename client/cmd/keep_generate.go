@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/generator"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+)
+
+// keepGenerateCmd represents the generate command
+var keepGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate password or passphrase",
+	Long: `Generate a random password or a diceware-style passphrase.
+The secret is printed to stdout, its entropy is printed to stderr.
+With --output json or yaml the secret, entropy and strength are printed
+together to stdout.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_generate"
+		log := logger.GetInstance().Log.With("op", op)
+
+		count, err := cmd.Flags().GetInt("count")
+		if err != nil {
+			log.Error("Error reading count flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if format == output.Env && count > 1 {
+			log.Error("env output accepts a single password, drop --count")
+			return
+		}
+
+		results := make([]output.Object, 0, count)
+		for i := 0; i < count; i++ {
+			secret, entropy, err := generateSecret(cmd.Flags())
+			if err != nil {
+				log.Error("Failed to generate password: ", slog.String("error", err.Error()))
+				return
+			}
+			if format == output.Table {
+				fmt.Println(secret)
+				printEntropy(entropy)
+				continue
+			}
+			results = append(results, output.Object{
+				{Key: "value", Value: secret},
+				{Key: "entropy", Value: math.Round(entropy*10) / 10},
+				{Key: "strength", Value: generator.Strength(entropy)},
+			})
+		}
+
+		switch {
+		case format == output.Table:
+		case format == output.Env:
+			err = output.WriteEnv(os.Stdout, "", results[0])
+		case count == 1:
+			err = output.Write(os.Stdout, format, results[0])
+		default:
+			err = output.Write(os.Stdout, format, results)
+		}
+		if err != nil {
+			log.Error("Failed to print passwords: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepGenerateCmd)
+
+	keepGenerateCmd.Flags().Int("count", 1, "Number of passwords to generate")
+	addGeneratorFlags(keepGenerateCmd.Flags())
+}
+
+// addGeneratorFlags регистрирует флаги генератора паролей
+func addGeneratorFlags(flags *pflag.FlagSet) {
+	flags.Int("length", 20, "Password length")
+	flags.Bool("lower", true, "Use lowercase letters")
+	flags.Bool("upper", true, "Use uppercase letters")
+	flags.Bool("digits", true, "Use digits")
+	flags.Bool("symbols", true, "Use symbols")
+	flags.Bool("exclude-ambiguous", false, "Exclude characters that look alike: Il1|O0o")
+	flags.Bool("passphrase", false, "Generate a passphrase from the embedded wordlist")
+	flags.Int("words", 6, "Number of passphrase words")
+	flags.String("separator", "-", "Passphrase word separator")
+	flags.Bool("capitalize", false, "Capitalize passphrase words")
+}
+
+// generateSecret генерирует пароль или фразу-пароль по флагам команды
+func generateSecret(flags *pflag.FlagSet) (string, float64, error) {
+	passphrase, err := flags.GetBool("passphrase")
+	if err != nil {
+		return "", 0, err
+	}
+
+	if passphrase {
+		opts := generator.PassphraseOptions{}
+		if opts.Words, err = flags.GetInt("words"); err != nil {
+			return "", 0, err
+		}
+		if opts.Separator, err = flags.GetString("separator"); err != nil {
+			return "", 0, err
+		}
+		if opts.Capitalize, err = flags.GetBool("capitalize"); err != nil {
+			return "", 0, err
+		}
+		if opts.Digit, err = flags.GetBool("digits"); err != nil {
+			return "", 0, err
+		}
+		// Цифра в фразе добавляется только по явному запросу
+		opts.Digit = opts.Digit && flags.Changed("digits")
+		return generator.Passphrase(opts)
+	}
+
+	opts := generator.Options{}
+	if opts.Length, err = flags.GetInt("length"); err != nil {
+		return "", 0, err
+	}
+	for flag, value := range map[string]*bool{
+		"lower":             &opts.Lower,
+		"upper":             &opts.Upper,
+		"digits":            &opts.Digits,
+		"symbols":           &opts.Symbols,
+		"exclude-ambiguous": &opts.ExcludeAmbiguous,
+	} {
+		if *value, err = flags.GetBool(flag); err != nil {
+			return "", 0, err
+		}
+	}
+	return generator.Password(opts)
+}
+
+// printEntropy выводит энтропию сгенерированного секрета в stderr,
+// чтобы stdout можно было передать другой программе
+func printEntropy(entropy float64) {
+	fmt.Fprintf(os.Stderr, "Entropy: %.1f bits (%s)\n", entropy, generator.Strength(entropy))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -16,46 +17,141 @@ import (
 var keepGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get secret",
+	Long: `Get one or more secrets by name.
+Several secrets are fetched with a single request: --name a --name b.
+
+The secret is printed in the --output format. With a single --field only
+its value is printed, binary data as is. With --file the binary content
+is written to a file instead of stdout.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		const op = "keep_get"
 		log := logger.GetInstance().Log.With("op", op)
 
-		name, err := cmd.Flags().GetString("name")
+		names, err := cmd.Flags().GetStringSlice("name")
 		if err != nil {
 			log.Error("Error reading secret name: ", slog.String("error", err.Error()))
+			return
+		}
+
+		itemID, err := cmd.Flags().GetString("id")
+		if err != nil {
+			log.Error("Error reading secret id: ", slog.String("error", err.Error()))
+			return
+		}
+
+		fields, err := cmd.Flags().GetStringSlice("field")
+		if err != nil {
+			log.Error("Error reading field flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		file, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Error("Error reading file flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if file != "" && len(names) > 1 {
+			log.Error("--file accepts a single secret")
+			return
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
 		}
 
 		token, err := tokenStorage.Load()
 		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
 			return
 		}
 
-		keeperClient := app.NewKeeperClient(app.GetKeeperConnection(token))
-		resp, err := keeperClient.GetItem(context.Background(), &v1.GetItemRequestV1{
-			Name: name,
-		})
+		conn, err := app.GetKeeperConnection(token)
 		if err != nil {
-			log.Error("Failed to create secret: ", slog.String("error", err.Error()))
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		if len(names) > 1 {
+			secrets := getItems(keeperClient, names, log)
+			if err := printSecrets(os.Stdout, format, secrets, fields, false); err != nil {
+				log.Error("Failed to print secrets: ", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		req := &v1.GetItemRequestV1{ItemId: itemID}
+		if len(names) == 1 {
+			req.Name = names[0]
+		}
+		resp, err := keeperClient.GetItem(context.Background(), req)
+		if err != nil {
+			log.Error("Failed to get secret: ", slog.String("error", err.Error()))
+			return
 		}
 
 		secret, err := decryptSecret(resp.GetContent())
 		if err != nil {
 			log.Error("Failed to decrypt secret: ", slog.String("error", err.Error()))
+			return
 		}
 
-		fmt.Printf("%s\n", secret)
+		if file != "" {
+			if err := writeBinaryFile(file, secret, fields); err != nil {
+				log.Error("Failed to write secret: ", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		info := &v1.SecretInfo{
+			Name:    resp.GetName(),
+			Version: resp.GetVersion(),
+			ItemId:  resp.GetItemId(),
+		}
+		if err := printSecrets(os.Stdout, format, []printedSecret{{info, secret}}, fields, true); err != nil {
+			log.Error("Failed to print secret: ", slog.String("error", err.Error()))
+		}
 	},
 }
 
 func init() {
-	const op = "keep_get"
-
 	keepCmd.AddCommand(keepGetCmd)
 
-	keepGetCmd.Flags().String("name", "", "Secret name")
-	if err := keepGetCmd.MarkFlagRequired("name"); err != nil {
-		slog.Error("Error setting flag: ",
-			slog.String("op", op),
-			slog.String("error", err.Error()))
+	keepGetCmd.Flags().StringSlice("name", nil, "Secret name")
+	registerFlagCompletion(keepGetCmd, "name", completeSecretNames)
+	keepGetCmd.Flags().String("id", "", "Secret id")
+	keepGetCmd.MarkFlagsOneRequired("name", "id")
+	keepGetCmd.MarkFlagsMutuallyExclusive("name", "id")
+	keepGetCmd.Flags().StringSlice("field", nil, "Print only these fields, e.g. --field password")
+	keepGetCmd.Flags().String("file", "", "Write binary content to this file")
+}
+
+// getItems загружает несколько секретов одним пакетным запросом.
+// Ненайденные секреты перечисляются в stderr.
+func getItems(keeperClient *app.KeeperClient, names []string, log *slog.Logger) []printedSecret {
+	resp, err := keeperClient.BatchGetItems(context.Background(), &v1.BatchGetItemsRequestV1{
+		Names: names,
+	})
+	if err != nil {
+		log.Error("Failed to get secrets: ", slog.String("error", err.Error()))
+		return nil
+	}
+
+	secrets := make([]printedSecret, 0, len(resp.GetSecrets()))
+	for _, info := range resp.GetSecrets() {
+		secret, err := decryptSecret(info.GetContent())
+		if err != nil {
+			log.Error("Failed to decrypt secret: ",
+				slog.String("name", info.GetName()),
+				slog.String("error", err.Error()))
+			continue
+		}
+		secrets = append(secrets, printedSecret{info: info, secret: secret})
+	}
+
+	for _, name := range resp.GetNotFound() {
+		fmt.Fprintf(os.Stderr, "%s: secret not found\n", name)
 	}
+	return secrets
 }
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/importer"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// keepImportCmd represents the import command
+var keepImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import secrets from another password manager",
+	Long: fmt.Sprintf(`Import secrets from an export file of another password manager.
+Supported formats: %s.
+
+Secrets whose names already exist on the server or repeat in the file are
+reported and skipped, use --rename to import them under a numbered name.
+Use --dry-run to see what would be created without uploading anything.`,
+		strings.Join(importer.Formats(), ", ")),
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_import"
+		log := logger.GetInstance().Log.With("op", op)
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Error("Error reading format flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Error("Error reading dry-run flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		rename, err := cmd.Flags().GetBool("rename")
+		if err != nil {
+			log.Error("Error reading rename flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		batchSize, err := cmd.Flags().GetInt("batch-size")
+		if err != nil {
+			log.Error("Error reading batch-size flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if batchSize < 1 {
+			batchSize = 1
+		}
+
+		folder, err := cmd.Flags().GetString("folder")
+		if err != nil {
+			log.Error("Error reading folder flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		tags, err := cmd.Flags().GetStringSlice("tag")
+		if err != nil {
+			log.Error("Error reading tag flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Error("Failed to read import file: ", slog.String("error", err.Error()))
+			return
+		}
+
+		records, err := importer.Parse(format, data)
+		if err != nil {
+			log.Error("Failed to parse import file: ", slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		existing := make(map[string]bool)
+		secrets, err := listAllItems(context.Background(), keeperClient, &v1.ListItemsRequestV1{})
+		if err != nil {
+			if !dryRun {
+				log.Error("Failed to list secret: ", slog.String("error", err.Error()))
+				return
+			}
+			log.Warn("Failed to list secret, collisions with existing secrets are not checked: ",
+				slog.String("error", err.Error()))
+		}
+		for _, info := range secrets {
+			existing[info.GetName()] = true
+		}
+
+		plan := planImport(records, existing, rename)
+		for i := range plan {
+			plan[i].record.Folder = path.Join(folder, plan[i].record.Folder)
+			plan[i].record.Tags = slices.Concat(plan[i].record.Tags, tags)
+		}
+
+		if dryRun {
+			printImportPlan(plan)
+			return
+		}
+
+		requests := make([]*v1.CreateItemRequestV1, 0, len(plan))
+		skipped := 0
+		for _, item := range plan {
+			if item.skip {
+				fmt.Printf("Skipped %s: %s\n", item.source, item.skipReason())
+				skipped++
+				continue
+			}
+
+			req, err := buildCreateItemRequest(item.name, item.record.Folder, item.record.Tags, item.record.Secret)
+			if err != nil {
+				log.Error("Failed to prepare secret: ",
+					slog.String("name", item.name),
+					slog.String("error", err.Error()))
+				skipped++
+				continue
+			}
+			requests = append(requests, req)
+		}
+
+		imported, failed := createItems(context.Background(), keeperClient, requests, batchSize, log)
+		fmt.Printf("Imported %d secrets, skipped %d, failed %d\n", imported, skipped, failed)
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepImportCmd)
+
+	keepImportCmd.Flags().String("format", "", "Export file format: "+strings.Join(importer.Formats(), ", "))
+	keepImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without creating secrets")
+	keepImportCmd.Flags().Bool("rename", false, "Import colliding secrets under a numbered name")
+	keepImportCmd.Flags().Int("batch-size", defaultBatchSize, "Number of secrets uploaded per request")
+	keepImportCmd.Flags().String("folder", "", "Folder to import secrets into")
+	registerFlagCompletion(keepImportCmd, "folder", completeFolders)
+	keepImportCmd.Flags().StringSlice("tag", nil, "Tags added to every imported secret")
+
+	if err := keepImportCmd.MarkFlagRequired("format"); err != nil {
+		slog.Error("Error setting flag: ",
+			slog.String("op", "keep_import"),
+			slog.String("error", err.Error()))
+	}
+}
+
+// importItem секрет из файла экспорта с именем, под которым он будет создан
+type importItem struct {
+	record importer.Record
+	source string
+	name   string
+	skip   bool
+	// duplicate имя повторяет более раннюю запись файла, а не секрет на сервере
+	duplicate bool
+}
+
+// skipReason объясняет, почему секрет пропущен
+func (i importItem) skipReason() string {
+	if i.duplicate {
+		return "duplicate name in import file"
+	}
+	return "secret already exists"
+}
+
+// planImport назначает секретам имена и отмечает коллизии с существующими
+// секретами и с другими записями файла.
+func planImport(records []importer.Record, existing map[string]bool, rename bool) []importItem {
+	taken := make(map[string]bool, len(existing)+len(records))
+	for name := range existing {
+		taken[name] = true
+	}
+
+	plan := make([]importItem, 0, len(records))
+	for i, record := range records {
+		source := strings.TrimSpace(record.Name)
+		if source == "" {
+			source = fmt.Sprintf("imported-%d", i+1)
+		}
+
+		item := importItem{record: record, source: source, name: source}
+		if taken[source] {
+			if rename {
+				item.name = uniqueName(source, taken)
+			} else {
+				item.skip = true
+				item.duplicate = !existing[source]
+			}
+		}
+
+		if !item.skip {
+			taken[item.name] = true
+		}
+		plan = append(plan, item)
+	}
+	return plan
+}
+
+// uniqueName подбирает свободное имя вида "name (2)"
+func uniqueName(name string, taken map[string]bool) string {
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s (%d)", name, n)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+func printImportPlan(plan []importItem) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NAME\tTYPE\tFOLDER\tACTION")
+
+	create := 0
+	for _, item := range plan {
+		action := "create"
+		switch {
+		case item.skip:
+			action = "skip: " + item.skipReason()
+		case item.name != item.source:
+			action = fmt.Sprintf("create, renamed from %q", item.source)
+		}
+		if !item.skip {
+			create++
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			item.name, item.record.Secret.Type(), item.record.Folder, action)
+	}
+	_ = writer.Flush()
+
+	fmt.Printf("\n%d secrets would be created, %d skipped\n", create, len(plan)-create)
+}
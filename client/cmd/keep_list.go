@@ -2,13 +2,19 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/ajugalushkin/goph-keeper/client/internal/app"
 	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
 	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
@@ -16,32 +22,207 @@ import (
 var keepListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List secrets",
+	Long: `List secrets metadata page by page.
+Secret contents are not downloaded unless --content is set.
+The env output format requires --content.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		const op = "keep_get"
+		const op = "keep_list"
 		log := logger.GetInstance().Log.With("op", op)
 
+		req, err := newListItemsRequest(cmd)
+		if err != nil {
+			log.Error("Error reading list flags: ", slog.String("error", err.Error()))
+			return
+		}
+
+		all, err := cmd.Flags().GetBool("all")
+		if err != nil {
+			log.Error("Error reading all flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		fields, err := cmd.Flags().GetStringSlice("field")
+		if err != nil {
+			log.Error("Error reading field flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+
 		token, err := tokenStorage.Load()
 		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
 			return
 		}
 
-		keeperClient := app.NewKeeperClient(app.GetKeeperConnection(token))
-		resp, err := keeperClient.ListItems(context.Background(), &v1.ListItemsRequestV1{})
+		conn, err := app.GetKeeperConnection(token)
 		if err != nil {
-			log.Error("Failed to list secret: ", slog.String("error", err.Error()))
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
 		}
+		keeperClient := app.NewKeeperClient(conn)
 
-		for _, info := range resp.GetSecrets() {
-			secret, err := decryptSecret(info.GetContent())
+		var secrets []*v1.SecretInfo
+		for {
+			resp, err := keeperClient.ListItems(context.Background(), req)
 			if err != nil {
-				log.Error("Failed to decrypt secret: ", slog.String("error", err.Error()))
+				log.Error("Failed to list secret: ", slog.String("error", err.Error()))
+				return
 			}
+			secrets = append(secrets, resp.GetSecrets()...)
 
-			fmt.Printf("%s\n", secret)
+			req.PageToken = resp.GetNextPageToken()
+			if req.GetPageToken() == "" || !all {
+				break
+			}
+		}
+
+		if err := printList(format, secrets, req.GetIncludeContent(), fields, req.GetPageToken()); err != nil {
+			log.Error("Failed to print secrets: ", slog.String("error", err.Error()))
 		}
 	},
 }
 
 func init() {
 	keepCmd.AddCommand(keepListCmd)
+
+	keepListCmd.Flags().String("type", "", "Filter by secret type")
+	registerFlagCompletion(keepListCmd, "type", completeSecretTypes)
+	keepListCmd.Flags().String("tag", "", "Filter by tag")
+	keepListCmd.Flags().String("folder", "", "Filter by folder")
+	registerFlagCompletion(keepListCmd, "folder", completeFolders)
+	keepListCmd.Flags().String("prefix", "", "Filter by secret name prefix")
+	keepListCmd.Flags().String("sort", "name", "Sort by: name, created, updated")
+	keepListCmd.Flags().Bool("desc", false, "Sort in descending order")
+	keepListCmd.Flags().Int32("page-size", 50, "Number of secrets per page")
+	keepListCmd.Flags().String("page-token", "", "Token of the page to list")
+	keepListCmd.Flags().Bool("all", false, "List all pages")
+	keepListCmd.Flags().Bool("content", false, "Download and show secret contents")
+	keepListCmd.Flags().StringSlice("field", nil, "Show only these fields of secret contents")
+}
+
+// listSortFields соответствие значений флага --sort полям сортировки
+var listSortFields = map[string]v1.ListItemsRequestV1_SortBy{
+	"name":    v1.ListItemsRequestV1_SORT_BY_NAME,
+	"created": v1.ListItemsRequestV1_SORT_BY_CREATED_AT,
+	"updated": v1.ListItemsRequestV1_SORT_BY_UPDATED_AT,
+}
+
+// newListItemsRequest собирает запрос списка секретов из флагов команды
+func newListItemsRequest(cmd *cobra.Command) (*v1.ListItemsRequestV1, error) {
+	flags := cmd.Flags()
+
+	sort, err := flags.GetString("sort")
+	if err != nil {
+		return nil, err
+	}
+	sortBy, ok := listSortFields[sort]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q", sort)
+	}
+
+	req := &v1.ListItemsRequestV1{
+		Filter: &v1.ListItemsRequestV1_Filter{},
+		SortBy: sortBy,
+	}
+
+	for flag, value := range map[string]*string{
+		"type":       &req.Filter.Type,
+		"tag":        &req.Filter.Tag,
+		"folder":     &req.Filter.Folder,
+		"prefix":     &req.Filter.NamePrefix,
+		"page-token": &req.PageToken,
+	} {
+		if *value, err = flags.GetString(flag); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Descending, err = flags.GetBool("desc"); err != nil {
+		return nil, err
+	}
+	if req.PageSize, err = flags.GetInt32("page-size"); err != nil {
+		return nil, err
+	}
+	if req.IncludeContent, err = flags.GetBool("content"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// printList выводит страницу списка секретов в выбранном формате
+func printList(format output.Format, secrets []*v1.SecretInfo, content bool, fields []string, nextPageToken string) error {
+	decrypted := make([]printedSecret, 0, len(secrets))
+	for _, info := range secrets {
+		s := printedSecret{info: info}
+		if content {
+			secret, err := decryptSecret(info.GetContent())
+			if err != nil {
+				return fmt.Errorf("secret %s: %w", info.GetName(), err)
+			}
+			s.secret = secret
+		}
+		decrypted = append(decrypted, s)
+	}
+
+	switch format {
+	case output.JSON, output.YAML:
+		items := make([]output.Object, 0, len(decrypted))
+		for _, s := range decrypted {
+			object, err := secretObject(s.info, s.secret, fields)
+			if err != nil {
+				return fmt.Errorf("secret %s: %w", s.info.GetName(), err)
+			}
+			items = append(items, object)
+		}
+		list := output.Object{{Key: "items", Value: items}}
+		if nextPageToken != "" {
+			list = append(list, output.Field{Key: "next_page_token", Value: nextPageToken})
+		}
+		return output.Write(os.Stdout, format, list)
+
+	case output.Env:
+		if !content {
+			return errors.New("env output requires --content")
+		}
+		return printSecrets(os.Stdout, format, decrypted, fields, false)
+
+	case output.Table:
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tTYPE\tFOLDER\tTAGS\tUPDATED\tVERSION")
+		for _, s := range decrypted {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				s.info.GetName(),
+				s.info.GetType(),
+				s.info.GetFolder(),
+				strings.Join(s.info.GetTags(), ","),
+				s.info.GetUpdatedAt().AsTime().Local().Format(time.DateTime),
+				s.info.GetVersion(),
+			)
+
+			if s.secret != nil {
+				values, err := output.SecretFields(s.secret, fields)
+				if err != nil {
+					return fmt.Errorf("secret %s: %w", s.info.GetName(), err)
+				}
+				for _, field := range values {
+					fmt.Fprintf(writer, "\t%s: %s\n", field.Key, tableValue(s.secret, field))
+				}
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+
+		if nextPageToken != "" {
+			fmt.Printf("\nMore secrets available, next page: --page-token %s\n", nextPageToken)
+		}
+		return nil
+	}
+	return output.ErrUnsupported{Format: format}
 }
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// keepMvCmd represents the mv command
+var keepMvCmd = &cobra.Command{
+	Use:   "mv <old> <new>",
+	Short: "Rename secret",
+	Long: `Rename a secret without downloading or re-uploading its content.
+The secret keeps its id and version. With --id the first argument
+is the secret id instead of its name.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSecretArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_mv"
+		log := logger.GetInstance().Log.With("op", op)
+
+		byID, err := cmd.Flags().GetBool("id")
+		if err != nil {
+			log.Error("Error reading id flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		req := &v1.RenameItemRequestV1{NewName: args[1]}
+		if byID {
+			req.ItemId = args[0]
+		} else {
+			req.Name = args[0]
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		resp, err := keeperClient.RenameItem(context.Background(), req)
+		if err != nil {
+			log.Error("Failed to rename secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), resp.GetItemId(),
+			fmt.Sprintf("Secret %s renamed to %s", args[0], resp.GetName())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepMvCmd)
+
+	keepMvCmd.Flags().Bool("id", false, "Treat the first argument as a secret id")
+}
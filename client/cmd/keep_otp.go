@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/otp"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// hotpSyncAttempts число попыток синхронизировать счетчик HOTP при конкурентных изменениях
+const hotpSyncAttempts = 5
+
+// keepOTPCmd represents the otp command
+var keepOTPCmd = &cobra.Command{
+	Use:   "otp <name>",
+	Short: "Print current one-time password",
+	Long: `Print current one-time password of TOTP or HOTP secret.
+For HOTP the counter is incremented on the server before the code is shown.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSecretArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_otp"
+		log := logger.GetInstance().Log.With("op", op)
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		for attempt := 0; attempt < hotpSyncAttempts; attempt++ {
+			resp, err := keeperClient.GetItem(context.Background(), &v1.GetItemRequestV1{
+				Name: args[0],
+			})
+			if err != nil {
+				log.Error("Failed to get secret: ", slog.String("error", err.Error()))
+				return
+			}
+
+			secret, err := decryptSecret(resp.GetContent())
+			if err != nil {
+				log.Error("Failed to decrypt secret: ", slog.String("error", err.Error()))
+				return
+			}
+
+			otpSecret, ok := secret.(vaulttypes.OTP)
+			if !ok {
+				log.Error("Secret is not a one-time password: ", slog.String("type", string(secret.Type())))
+				return
+			}
+
+			if otpSecret.Kind == otp.KindTOTP {
+				code, remaining, err := otp.TOTP(otpSecret, time.Now())
+				if err != nil {
+					log.Error("Failed to generate code: ", slog.String("error", err.Error()))
+					return
+				}
+				result := output.Object{
+					{Key: "name", Value: resp.GetName()},
+					{Key: "code", Value: code},
+					{Key: "remaining", Value: remaining},
+				}
+				if err := printResult(format, result, fmt.Sprintf("%s (%ds remaining)", code, remaining)); err != nil {
+					log.Error("Failed to print code: ", slog.String("error", err.Error()))
+				}
+				return
+			}
+
+			code, err := otp.HOTP(otpSecret)
+			if err != nil {
+				log.Error("Failed to generate code: ", slog.String("error", err.Error()))
+				return
+			}
+
+			// Код показываем только после сохранения нового значения счетчика,
+			// иначе один и тот же код может быть выдан дважды.
+			otpSecret.Counter++
+			content, err := encryptSecret(otpSecret)
+			if err != nil {
+				log.Error("Failed to encrypt secret: ", slog.String("error", err.Error()))
+				return
+			}
+
+			_, err = keeperClient.UpdateItem(context.Background(), &v1.UpdateItemRequestV1{
+				Name:    resp.GetName(),
+				Content: content,
+				Version: resp.GetVersion(),
+			})
+			if err != nil {
+				if status.Code(err) == codes.Aborted {
+					log.Debug("HOTP counter changed concurrently, retrying")
+					continue
+				}
+				log.Error("Failed to sync HOTP counter: ", slog.String("error", err.Error()))
+				return
+			}
+
+			result := output.Object{
+				{Key: "name", Value: resp.GetName()},
+				{Key: "code", Value: code},
+				{Key: "counter", Value: otpSecret.Counter - 1},
+			}
+			if err := printResult(format, result, fmt.Sprintf("%s (counter %d)", code, otpSecret.Counter-1)); err != nil {
+				log.Error("Failed to print code: ", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		log.Error("Failed to sync HOTP counter: too many concurrent updates")
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepOTPCmd)
+}
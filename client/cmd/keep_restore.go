@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/archive"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+const (
+	conflictSkip      = "skip"
+	conflictOverwrite = "overwrite"
+	conflictRename    = "rename"
+)
+
+// keepRestoreCmd represents the restore command
+var keepRestoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Restore secrets from an archive",
+	Long: `Restore secrets from an archive created by "keep export".
+The archive can be restored into the same or another account.
+
+Secrets that already exist are handled according to --conflict:
+  skip       keep the existing secret (default)
+  overwrite  replace the existing secret content, folder and tags
+  rename     restore the secret under a numbered name
+Secrets repeating a name earlier in the archive are skipped unless
+--conflict rename is used.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_restore"
+		log := logger.GetInstance().Log.With("op", op)
+
+		conflict, err := cmd.Flags().GetString("conflict")
+		if err != nil {
+			log.Error("Error reading conflict flag: ", slog.String("error", err.Error()))
+			return
+		}
+		switch conflict {
+		case conflictSkip, conflictOverwrite, conflictRename:
+		default:
+			log.Error("Unknown conflict policy: ", slog.String("conflict", conflict))
+			return
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Error("Failed to read archive: ", slog.String("error", err.Error()))
+			return
+		}
+
+		var password string
+		if archive.IsEncrypted(data) {
+			if password, err = readSecretFlag(cmd, "password", "Archive password", false); err != nil {
+				log.Error("Error reading password flag: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		vaultArchive, err := archive.Read(data, []byte(password))
+		if err != nil {
+			log.Error("Failed to read archive: ", slog.String("error", err.Error()))
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+		secrets, err := listAllItems(context.Background(), keeperClient, &v1.ListItemsRequestV1{})
+		if err != nil {
+			log.Error("Failed to list secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		versions := make(map[string]string, len(secrets))
+		for _, info := range secrets {
+			versions[info.GetName()] = info.GetVersion()
+		}
+
+		taken := make(map[string]bool, len(versions))
+		for name := range versions {
+			taken[name] = true
+		}
+		// restored имена, уже занятые секретами из этого архива
+		restored := make(map[string]bool, len(vaultArchive.Items))
+
+		requests := make([]*v1.CreateItemRequestV1, 0, len(vaultArchive.Items))
+		updates := make([]*v1.UpdateItemRequestV1, 0)
+		skipped, failed := 0, 0
+		for _, item := range vaultArchive.Items {
+			secret, err := vaulttypes.DecodeVault(item.Secret)
+			if err != nil {
+				log.Error("Failed to decode secret: ",
+					slog.String("name", item.Name),
+					slog.String("error", err.Error()))
+				failed++
+				continue
+			}
+
+			name := item.Name
+			if taken[name] {
+				switch {
+				case conflict == conflictRename:
+					name = uniqueName(name, taken)
+				case restored[name]:
+					// Секрет с этим именем уже восстановлен из архива, у него
+					// еще нет версии на сервере
+					fmt.Printf("Skipped %s: duplicate name in archive\n", name)
+					skipped++
+					continue
+				case conflict == conflictSkip:
+					fmt.Printf("Skipped %s: secret already exists\n", name)
+					skipped++
+					continue
+				case conflict == conflictOverwrite:
+					restored[name] = true
+					content, err := encryptSecret(secret)
+					if err != nil {
+						log.Error("Failed to prepare secret: ",
+							slog.String("name", name),
+							slog.String("error", err.Error()))
+						failed++
+						continue
+					}
+					updates = append(updates, &v1.UpdateItemRequestV1{
+						Name:    name,
+						Content: content,
+						Version: versions[name],
+						Metadata: &v1.ItemMetadataV1{
+							Type:   string(secret.Type()),
+							Folder: item.Folder,
+							Tags:   item.Tags,
+						},
+					})
+					continue
+				}
+			}
+			taken[name] = true
+			restored[name] = true
+
+			req, err := buildCreateItemRequest(name, item.Folder, item.Tags, secret)
+			if err != nil {
+				log.Error("Failed to prepare secret: ",
+					slog.String("name", name),
+					slog.String("error", err.Error()))
+				failed++
+				continue
+			}
+			requests = append(requests, req)
+		}
+
+		created, createFailed := createItems(context.Background(), keeperClient, requests, defaultBatchSize, log)
+		overwritten, updateFailed := updateItems(context.Background(), keeperClient, updates, defaultBatchSize, log)
+		fmt.Printf("Restored %d secrets, overwritten %d, skipped %d, failed %d\n",
+			created, overwritten, skipped, failed+createFailed+updateFailed)
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepRestoreCmd)
+
+	addSecretFlag(keepRestoreCmd, "password", "", "Archive password", true)
+	keepRestoreCmd.Flags().String("conflict", conflictSkip, "Conflict policy: skip, overwrite, rename")
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/search"
+)
+
+// keepSearchCmd represents the search command
+var keepSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search secrets",
+	Long: `Search secrets by name, folder, tags and non-sensitive fields:
+credentials login, text body, card holder and notes.
+Secrets are decrypted and searched locally, the query never leaves the client.
+The local cache keeps only names, folders, tags and these non-sensitive
+fields, encrypted with a key from the system keyring. --offline searches
+it without contacting the server.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "keep_search"
+		log := logger.GetInstance().Log.With("op", op)
+
+		offline, err := cmd.Flags().GetBool("offline")
+		if err != nil {
+			log.Error("Error reading offline flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		limit, err := cmd.Flags().GetInt("limit")
+		if err != nil {
+			log.Error("Error reading limit flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		vaultCache, err := openCache()
+		if err != nil {
+			log.Error("Failed to open local cache: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if !offline {
+			token, err := tokenStorage.Load()
+			if err != nil {
+				log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+				return
+			}
+
+			conn, err := app.GetKeeperConnection(token)
+			if err != nil {
+				log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+				return
+			}
+			keeperClient := app.NewKeeperClient(conn)
+			if _, err := syncCache(context.Background(), keeperClient, vaultCache); err != nil {
+				log.Warn("Failed to sync local cache, searching cached secrets: ",
+					slog.String("error", err.Error()))
+			}
+		}
+
+		docs := make([]search.Document, 0, len(vaultCache.Items))
+		for _, item := range vaultCache.List() {
+			docs = append(docs, search.NewDocument(item.Name, item.Type, item.Folder, item.Tags, item.Fields))
+		}
+
+		results := search.Find(strings.Join(args, " "), docs, limit)
+		if err := printSearchResults(format, results); err != nil {
+			log.Error("Failed to print results: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	keepCmd.AddCommand(keepSearchCmd)
+
+	keepSearchCmd.Flags().Bool("offline", false, "Search the local cache without syncing")
+	keepSearchCmd.Flags().Int("limit", 10, "Maximum number of results")
+}
+
+// printSearchResults выводит найденные секреты в выбранном формате
+func printSearchResults(format output.Format, results []search.Result) error {
+	switch format {
+	case output.JSON, output.YAML:
+		list := make([]output.Object, 0, len(results))
+		for _, result := range results {
+			list = append(list, output.Object{
+				{Key: "name", Value: result.Name},
+				{Key: "type", Value: result.Type},
+				{Key: "matched", Value: result.Field},
+				{Key: "score", Value: result.Score},
+			})
+		}
+		return output.Write(os.Stdout, format, list)
+
+	case output.Table:
+		if len(results) == 0 {
+			fmt.Println("No secrets found")
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "NAME\tTYPE\tMATCHED")
+		for _, result := range results {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Name, result.Type, result.Field)
+		}
+		return writer.Flush()
+	}
+	return output.ErrUnsupported{Format: format}
+}
@@ -1,18 +1,13 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
 // keepUpdateCmd represents the update command
 var keepUpdateCmd = &cobra.Command{
 	Use:   "update",
-	Short: "A brief description of your command",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("update called")
-	},
+	Short: "Update secret",
 }
 
 func init() {
@@ -1,20 +1,142 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
-// credentialsCmd represents the credentials command
+// keepUpdateCredentialsCmd represents the credentials command
 var keepUpdateCredentialsCmd = &cobra.Command{
 	Use:   "credentials",
-	Short: "A brief description of your command",
+	Short: "Update credentials secret",
+	Long: `Update fields of a credentials secret.
+Only the fields passed as flags are changed.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("credentials called")
+		const op = "keep update credentials"
+		log := logger.GetInstance().Log.With("op", op)
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			log.Error("Unable to get `name` arg: ", slog.String("error", err.Error()))
+			return
+		}
+
+		generate, err := cmd.Flags().GetBool("generate")
+		if err != nil {
+			log.Error("Unable to get `generate` arg: ", slog.String("error", err.Error()))
+			return
+		}
+		if generate && secretFlagSet(cmd, "password") {
+			log.Error("--generate can not be combined with --password")
+			return
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+		keeperClient := app.NewKeeperClient(conn)
+
+		item, err := keeperClient.GetItem(context.Background(), &v1.GetItemRequestV1{Name: name})
+		if err != nil {
+			log.Error("Failed to get secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		secret, err := decryptSecret(item.GetContent())
+		if err != nil {
+			log.Error("Failed to decrypt secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		credentials, ok := secret.(vaulttypes.Credentials)
+		if !ok {
+			log.Error("Secret is not credentials: ", slog.String("type", string(secret.Type())))
+			return
+		}
+
+		for flag, value := range map[string]*string{
+			"login": &credentials.Login,
+			"url":   &credentials.URL,
+			"notes": &credentials.Notes,
+		} {
+			if !cmd.Flags().Changed(flag) {
+				continue
+			}
+			if *value, err = cmd.Flags().GetString(flag); err != nil {
+				log.Error("Unable to get arg: ",
+					slog.String("flag", flag),
+					slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		var entropy float64
+		if generate {
+			credentials.Password, entropy, err = generateSecret(cmd.Flags())
+			if err != nil {
+				log.Error("Failed to generate password: ", slog.String("error", err.Error()))
+				return
+			}
+		} else if secretFlagSet(cmd, "password") {
+			if credentials.Password, err = readSecretFlag(cmd, "password", "Password", false); err != nil {
+				log.Error("Unable to get `password` arg: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		content, err := encryptSecret(credentials)
+		if err != nil {
+			log.Error("Failed to prepare secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		resp, err := keeperClient.UpdateItem(context.Background(), &v1.UpdateItemRequestV1{
+			Name:    name,
+			Content: content,
+			Version: item.GetVersion(),
+		})
+		if err != nil {
+			log.Error("Failed to update secret: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := printItemResult(cmd, resp.GetName(), resp.GetVersion(), "",
+			fmt.Sprintf("Secret %s version %v updated successfully", resp.GetName(), resp.GetVersion())); err != nil {
+			log.Error("Failed to print result: ", slog.String("error", err.Error()))
+		}
+		if generate {
+			printEntropy(entropy)
+		}
 	},
 }
 
 func init() {
 	keepUpdateCmd.AddCommand(keepUpdateCredentialsCmd)
+
+	keepUpdateCredentialsCmd.Flags().String("name", "", "Secret name")
+	if err := keepUpdateCredentialsCmd.MarkFlagRequired("name"); err != nil {
+		slog.Error("Unable to mark 'name' flag as required %s", slog.String("error", err.Error()))
+	}
+	registerFlagCompletion(keepUpdateCredentialsCmd, "name", completeSecretNames)
+	keepUpdateCredentialsCmd.Flags().String("login", "", "Login")
+	addSecretFlag(keepUpdateCredentialsCmd, "password", "", "New password", false)
+	keepUpdateCredentialsCmd.Flags().Bool("generate", false, "Generate a new password")
+	keepUpdateCredentialsCmd.Flags().String("url", "", "Site URL")
+	keepUpdateCredentialsCmd.Flags().String("notes", "", "Notes")
+	addGeneratorFlags(keepUpdateCredentialsCmd.Flags())
 }
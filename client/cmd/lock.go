@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/session"
+)
+
+// lockCmd represents the lock command
+var lockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Lock the vault",
+	Long:  `Wipe the vault key held by the session agent and stop the agent.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "lock"
+		log := logger.GetInstance().Log.With("op", op)
+
+		err := session.NewClient(sessionSocket()).Lock()
+		switch {
+		case errors.Is(err, session.ErrNotRunning), errors.Is(err, session.ErrLocked):
+			fmt.Println("Vault is not unlocked")
+		case err != nil:
+			log.Error("Failed to lock vault: ", slog.String("error", err.Error()))
+		default:
+			fmt.Println("Vault locked")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lockCmd)
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+func init() {
+	rootCmd.PersistentFlags().String("output", string(output.Table),
+		"Output format: "+strings.Join(output.Formats(), ", "))
+}
+
+// outputFormat возвращает формат вывода из флага --output,
+// а если он не задан, из параметра output конфигурации
+func outputFormat(cmd *cobra.Command) (output.Format, error) {
+	value := config.GetInstance().Config.Output
+	if value == "" || cmd.Flags().Changed("output") {
+		var err error
+		if value, err = cmd.Flags().GetString("output"); err != nil {
+			return "", err
+		}
+	}
+	return output.Parse(value)
+}
+
+// secretObject описание секрета для вывода: метаданные и поля по схеме типа.
+// Если secret равен nil, поля не выводятся.
+func secretObject(info *v1.SecretInfo, secret vaulttypes.Vault, fields []string) (output.Object, error) {
+	object := output.Object{{Key: "name", Value: info.GetName()}}
+
+	secretType := info.GetType()
+	if secret != nil {
+		secretType = string(secret.Type())
+	}
+	object = append(object, output.Field{Key: "type", Value: secretType})
+
+	if info.GetFolder() != "" {
+		object = append(object, output.Field{Key: "folder", Value: info.GetFolder()})
+	}
+	if len(info.GetTags()) > 0 {
+		object = append(object, output.Field{Key: "tags", Value: info.GetTags()})
+	}
+	object = append(object, output.Field{Key: "version", Value: info.GetVersion()})
+	if info.GetItemId() != "" {
+		object = append(object, output.Field{Key: "item_id", Value: info.GetItemId()})
+	}
+	if info.GetCreatedAt() != nil {
+		object = append(object, output.Field{Key: "created_at", Value: info.GetCreatedAt().AsTime().Format(time.RFC3339)})
+	}
+	if info.GetUpdatedAt() != nil {
+		object = append(object, output.Field{Key: "updated_at", Value: info.GetUpdatedAt().AsTime().Format(time.RFC3339)})
+	}
+
+	if secret != nil {
+		values, err := output.SecretFields(secret, fields)
+		if err != nil {
+			return nil, err
+		}
+		object = append(object, output.Field{Key: "fields", Value: values})
+	}
+	return object, nil
+}
+
+// printedSecret расшифрованный секрет с метаданными
+type printedSecret struct {
+	info   *v1.SecretInfo
+	secret vaulttypes.Vault
+}
+
+// printSecrets выводит секреты в выбранном формате. Одиночный секрет
+// выводится объектом, несколько секретов списком. В формате table
+// единственное выбранное поле печатается без оформления, двоичные
+// данные как есть, чтобы значение можно было передать другой программе.
+func printSecrets(w io.Writer, format output.Format, secrets []printedSecret, fields []string, single bool) error {
+	switch format {
+	case output.JSON, output.YAML:
+		objects := make([]output.Object, 0, len(secrets))
+		for _, s := range secrets {
+			object, err := secretObject(s.info, s.secret, fields)
+			if err != nil {
+				return fmt.Errorf("secret %s: %w", s.info.GetName(), err)
+			}
+			objects = append(objects, object)
+		}
+		if single && len(objects) == 1 {
+			return output.Write(w, format, objects[0])
+		}
+		return output.Write(w, format, objects)
+
+	case output.Env:
+		for _, s := range secrets {
+			values, err := output.SecretFields(s.secret, fields)
+			if err != nil {
+				return fmt.Errorf("secret %s: %w", s.info.GetName(), err)
+			}
+			prefix := ""
+			if !single || len(secrets) > 1 {
+				prefix = s.info.GetName()
+			}
+			if err := output.WriteEnv(w, prefix, values); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case output.Table:
+		if len(fields) == 1 {
+			for _, s := range secrets {
+				value, err := output.Binary(s.secret, fields[0])
+				if err != nil {
+					return fmt.Errorf("secret %s: %w", s.info.GetName(), err)
+				}
+				if _, err := w.Write(value); err != nil {
+					return err
+				}
+				if !output.IsBinary(s.secret, fields[0]) {
+					fmt.Fprintln(w)
+				}
+			}
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for i, s := range secrets {
+			values, err := output.SecretFields(s.secret, fields)
+			if err != nil {
+				return fmt.Errorf("secret %s: %w", s.info.GetName(), err)
+			}
+			if i > 0 {
+				fmt.Fprintln(writer)
+			}
+			fmt.Fprintf(writer, "NAME\t%s\n", s.info.GetName())
+			fmt.Fprintf(writer, "TYPE\t%s\n", s.secret.Type())
+			for _, field := range values {
+				fmt.Fprintf(writer, "%s\t%s\n", field.Key, tableValue(s.secret, field))
+			}
+		}
+		return writer.Flush()
+	}
+	return output.ErrUnsupported{Format: format}
+}
+
+// tableValue значение поля для таблицы: двоичные данные не печатаются
+func tableValue(secret vaulttypes.Vault, field output.Field) string {
+	if field.Value == nil {
+		return ""
+	}
+	value := fmt.Sprint(field.Value)
+	if output.IsBinary(secret, field.Key) && value != "" {
+		return fmt.Sprintf("<binary, %d bytes, use --field %s or --file>", len(value)*3/4, field.Key)
+	}
+	return value
+}
+
+// printResult выводит результат изменения секрета. В формате table
+// печатается сообщение message.
+func printResult(format output.Format, result output.Object, message string) error {
+	switch format {
+	case output.Table:
+		_, err := fmt.Fprintln(os.Stdout, message)
+		return err
+	case output.Env:
+		return output.WriteEnv(os.Stdout, "", result)
+	}
+	return output.Write(os.Stdout, format, result)
+}
+
+// printItemResult выводит имя и версию созданного или измененного секрета
+func printItemResult(cmd *cobra.Command, name, version, itemID, message string) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	result := output.Object{
+		{Key: "name", Value: name},
+		{Key: "version", Value: version},
+	}
+	if itemID != "" {
+		result = append(result, output.Field{Key: "item_id", Value: itemID})
+	}
+	return printResult(format, result, message)
+}
+
+// writeBinaryFile записывает двоичное содержимое секрета в файл,
+// доступный только владельцу
+func writeBinaryFile(path string, secret vaulttypes.Vault, fields []string) error {
+	if len(fields) > 1 {
+		return errors.New("--file accepts a single --field")
+	}
+	field := ""
+	if len(fields) == 1 {
+		field = fields[0]
+	}
+
+	data, err := output.Binary(secret, field)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
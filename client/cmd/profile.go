@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/profile"
+	"github.com/ajugalushkin/goph-keeper/client/internal/token"
+)
+
+// activeProfile профиль сервера, выбранный для команды.
+// Пустое имя означает секцию client файла конфигурации.
+var activeProfile profile.Entry
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage server profiles",
+	Long: `A profile is a named server with its own address, TLS settings,
+account and access token, e.g. staging and production.
+
+The profile is selected by --profile, then by the ` + profile.EnvName + `
+environment variable, then by "profile use", then by the profile key of
+the config file. Without a profile the client section of the config is used.
+
+Profiles may be defined in the profiles section of the config file or
+added with "profile add". Names use lowercase letters, digits, '.', '_'
+and '-'.`,
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+
+	rootCmd.PersistentFlags().String("profile", "", "Server profile, overrides "+profile.EnvName)
+	registerFlagCompletion(rootCmd, "profile", completeProfiles)
+}
+
+// openProfiles открывает профили файла конфигурации и добавленные командами
+func openProfiles() (*profile.Set, error) {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	store, err := profile.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewSet(store, config.GetInstance().Config), nil
+}
+
+// applyProfile выбирает профиль сервера и переключает на него параметры
+// соединения и хранилище токена. Команды profile работают без выбора
+// профиля, чтобы можно было исправить неверный выбор.
+func applyProfile(cmd *cobra.Command) error {
+	if cmd == profileCmd || cmd.Parent() == profileCmd {
+		return nil
+	}
+
+	explicit, err := cmd.Flags().GetString("profile")
+	if err != nil {
+		return err
+	}
+	profiles, err := openProfiles()
+	if err != nil {
+		return err
+	}
+
+	name := profiles.Current(explicit)
+	if name == "" {
+		return nil
+	}
+	entry, err := profiles.Get(name)
+	if err != nil {
+		return fmt.Errorf("%w, see \"profile list\"", err)
+	}
+
+	cfg := &config.GetInstance().Config
+	cfg.Client = profile.Apply(cfg.Client, entry.Profile)
+	tokenStorage = token.NewEncryptedStorage(entry.Name)
+	activeProfile = entry
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/profile"
+)
+
+// profileAddCmd represents the profile add command
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add server profile",
+	Long: `Add a server profile. With --force an existing profile is replaced,
+its access token is kept. With --use the profile becomes the default.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "profile_add"
+		log := logger.GetInstance().Log.With("op", op)
+
+		name := args[0]
+		if err := profile.ValidateName(name); err != nil {
+			log.Error("Failed to add profile: ", slog.String("error", err.Error()))
+			return
+		}
+
+		var (
+			p   config.Profile
+			err error
+		)
+		for flag, value := range map[string]*string{
+			"address":     &p.Address,
+			"account":     &p.Account,
+			"ca":          &p.TLS.CA,
+			"server-name": &p.TLS.ServerName,
+		} {
+			if *value, err = cmd.Flags().GetString(flag); err != nil {
+				log.Error("Error reading flag: ", slog.String("flag", flag), slog.String("error", err.Error()))
+				return
+			}
+		}
+		for flag, value := range map[string]*bool{
+			"tls":                  &p.TLS.Enabled,
+			"insecure-skip-verify": &p.TLS.Insecure,
+		} {
+			if *value, err = cmd.Flags().GetBool(flag); err != nil {
+				log.Error("Error reading flag: ", slog.String("flag", flag), slog.String("error", err.Error()))
+				return
+			}
+		}
+		// Сертификат и имя сервера имеют смысл только с TLS
+		p.TLS.Enabled = p.TLS.Enabled || p.TLS.CA != "" || p.TLS.ServerName != "" || p.TLS.Insecure
+
+		force, err := cmd.Flags().GetBool("force")
+		if err != nil {
+			log.Error("Error reading force flag: ", slog.String("error", err.Error()))
+			return
+		}
+		use, err := cmd.Flags().GetBool("use")
+		if err != nil {
+			log.Error("Error reading use flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		profiles, err := openProfiles()
+		if err != nil {
+			log.Error("Failed to open profiles: ", slog.String("error", err.Error()))
+			return
+		}
+		if existing, err := profiles.Get(name); err == nil {
+			switch {
+			case existing.Source == profile.SourceConfig:
+				log.Error("Failed to add profile: ", slog.String("error", fmt.Errorf("%w: %s", profile.ErrReadOnly, name).Error()))
+				return
+			case !force:
+				log.Error("Failed to add profile: ", slog.String("error", fmt.Errorf("%w: %s, use --force to replace it", profile.ErrExists, name).Error()))
+				return
+			}
+		} else if !errors.Is(err, profile.ErrNotFound) {
+			log.Error("Failed to add profile: ", slog.String("error", err.Error()))
+			return
+		}
+
+		store := profiles.Store()
+		store.Profiles[name] = p
+		if use {
+			store.Current = name
+		}
+		if err := store.Save(); err != nil {
+			log.Error("Failed to save profiles: ", slog.String("error", err.Error()))
+			return
+		}
+
+		fmt.Printf("Profile %s added\n", name)
+		if use {
+			fmt.Printf("Using profile %s\n", name)
+		}
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileAddCmd)
+
+	profileAddCmd.Flags().String("address", "", "Server address, host:port")
+	if err := profileAddCmd.MarkFlagRequired("address"); err != nil {
+		slog.Error("Error setting flag: ", slog.String("error", err.Error()))
+	}
+	profileAddCmd.Flags().String("account", "", "Account email used by auth login")
+	profileAddCmd.Flags().Bool("tls", false, "Connect over TLS")
+	profileAddCmd.Flags().String("ca", "", "CA certificate file, system roots by default")
+	profileAddCmd.Flags().String("server-name", "", "Server name to verify the certificate against")
+	profileAddCmd.Flags().Bool("insecure-skip-verify", false, "Do not verify the server certificate")
+	profileAddCmd.Flags().Bool("force", false, "Replace an existing profile")
+	profileAddCmd.Flags().Bool("use", false, "Make the profile the default")
+}
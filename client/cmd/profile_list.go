@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/output"
+	"github.com/ajugalushkin/goph-keeper/client/internal/profile"
+)
+
+// profileListCmd represents the profile list command
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List server profiles",
+	Long:  `List server profiles. The selected profile is marked with *.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "profile_list"
+		log := logger.GetInstance().Log.With("op", op)
+
+		format, err := outputFormat(cmd)
+		if err != nil {
+			log.Error("Error reading output flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		explicit, err := cmd.Flags().GetString("profile")
+		if err != nil {
+			log.Error("Error reading profile flag: ", slog.String("error", err.Error()))
+			return
+		}
+
+		profiles, err := openProfiles()
+		if err != nil {
+			log.Error("Failed to open profiles: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := printProfiles(format, profiles.List(), profiles.Current(explicit)); err != nil {
+			log.Error("Failed to print profiles: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd)
+}
+
+// printProfiles выводит профили в выбранном формате
+func printProfiles(format output.Format, entries []profile.Entry, current string) error {
+	switch format {
+	case output.JSON, output.YAML:
+		list := make([]output.Object, 0, len(entries))
+		for _, entry := range entries {
+			list = append(list, output.Object{
+				{Key: "name", Value: entry.Name},
+				{Key: "address", Value: entry.Address},
+				{Key: "account", Value: entry.Account},
+				{Key: "tls", Value: entry.TLS.Enabled},
+				{Key: "source", Value: entry.Source},
+				{Key: "current", Value: entry.Name == current},
+			})
+		}
+		return output.Write(os.Stdout, format, list)
+
+	case output.Table:
+		if len(entries) == 0 {
+			fmt.Println("No profiles, add one with \"profile add\"")
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "\tNAME\tADDRESS\tACCOUNT\tTLS\tSOURCE")
+		for _, entry := range entries {
+			marker := ""
+			if entry.Name == current {
+				marker = "*"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%t\t%s\n",
+				marker, entry.Name, entry.Address, entry.Account, entry.TLS.Enabled, entry.Source)
+		}
+		return writer.Flush()
+	}
+	return output.ErrUnsupported{Format: format}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/profile"
+	"github.com/ajugalushkin/goph-keeper/client/internal/token"
+)
+
+// profileRemoveCmd represents the profile remove command
+var profileRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove server profile",
+	Long: `Remove a profile added with "profile add" together with its access token.
+Profiles defined in the config file are removed by editing the file.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfileArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "profile_remove"
+		log := logger.GetInstance().Log.With("op", op)
+
+		profiles, err := openProfiles()
+		if err != nil {
+			log.Error("Failed to open profiles: ", slog.String("error", err.Error()))
+			return
+		}
+
+		entry, err := profiles.Get(args[0])
+		if err != nil {
+			log.Error("Failed to remove profile: ", slog.String("error", err.Error()))
+			return
+		}
+		if entry.Source == profile.SourceConfig {
+			log.Error("Failed to remove profile: ",
+				slog.String("error", fmt.Errorf("%w: %s", profile.ErrReadOnly, entry.Name).Error()))
+			return
+		}
+
+		store := profiles.Store()
+		delete(store.Profiles, entry.Name)
+		if store.Current == entry.Name {
+			store.Current = ""
+		}
+		if err := store.Save(); err != nil {
+			log.Error("Failed to save profiles: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if err := token.NewEncryptedStorage(entry.Name).Delete(); err != nil {
+			log.Warn("Failed to delete access token: ", slog.String("error", err.Error()))
+		}
+		fmt.Printf("Profile %s removed\n", entry.Name)
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileRemoveCmd)
+}
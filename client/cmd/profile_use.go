@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/profile"
+)
+
+// profileUseCmd represents the profile use command
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Select default server profile",
+	Long: `Select the profile used when neither --profile nor ` + profile.EnvName + `
+is set. With --none the selection is cleared.`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeProfileArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "profile_use"
+		log := logger.GetInstance().Log.With("op", op)
+
+		none, err := cmd.Flags().GetBool("none")
+		if err != nil {
+			log.Error("Error reading none flag: ", slog.String("error", err.Error()))
+			return
+		}
+		if none == (len(args) == 1) {
+			log.Error("Pass a profile name or --none")
+			return
+		}
+
+		profiles, err := openProfiles()
+		if err != nil {
+			log.Error("Failed to open profiles: ", slog.String("error", err.Error()))
+			return
+		}
+
+		name := ""
+		if !none {
+			entry, err := profiles.Get(args[0])
+			if err != nil {
+				log.Error("Failed to select profile: ", slog.String("error", err.Error()))
+				return
+			}
+			name = entry.Name
+		}
+
+		store := profiles.Store()
+		store.Current = name
+		if err := store.Save(); err != nil {
+			log.Error("Failed to save profiles: ", slog.String("error", err.Error()))
+			return
+		}
+
+		if name == "" {
+			fmt.Println("Profile selection cleared")
+		} else {
+			fmt.Printf("Using profile %s\n", name)
+		}
+		if env := os.Getenv(profile.EnvName); env != "" {
+			fmt.Fprintf(os.Stderr, "Warning: %s=%s overrides the selection\n", profile.EnvName, env)
+		}
+	},
+}
+
+func init() {
+	profileCmd.AddCommand(profileUseCmd)
+
+	profileUseCmd.Flags().Bool("none", false, "Clear the selection and use the client section of the config")
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/render"
+	"github.com/ajugalushkin/goph-keeper/client/internal/secretref"
+)
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use:   "render <template>",
+	Short: "Render a config file template with secrets",
+	Long: `Render a Go text/template file, substituting secrets from the vault.
+
+Functions available in the template:
+  {{ secret "prod-db" "password" }}   field of a secret
+  {{ secret "api-token" }}            secret with a single field
+  {{ ref "keeper://prod-db/user" }}   secret reference
+
+Every referenced secret is fetched once per run. If a secret or a field
+is missing, rendering fails and nothing is written. Use "-" to read the
+template from stdin. The result is written to stdout or, with -f, to a
+file created with mode 0600.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "render"
+		// stdout занят отрисованным шаблоном
+		logger.UseStderr()
+		log := logger.GetInstance().Log.With("op", op)
+
+		output, err := cmd.Flags().GetString("file")
+		if err != nil {
+			log.Error("Error reading file flag: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		var text []byte
+		if args[0] == "-" {
+			text, err = io.ReadAll(os.Stdin)
+		} else {
+			text, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			log.Error("Failed to read template: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		tmpl, err := render.Parse(filepath.Base(args[0]), string(text))
+		if err != nil {
+			log.Error("Invalid template: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		resolver := newSecretResolver(app.NewKeeperClient(conn))
+
+		if output == "" {
+			if err := tmpl.Execute(context.Background(), resolver, os.Stdout); err != nil {
+				log.Error("Failed to render template: ", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+			return
+		}
+
+		if err := renderToFile(context.Background(), tmpl, resolver, output); err != nil {
+			log.Error("Failed to render template: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+
+	renderCmd.Flags().StringP("file", "f", "", "Output file, stdout by default")
+}
+
+// renderToFile выполняет шаблон во временный файл и заменяет им path,
+// чтобы при ошибке не оставить частично записанный файл
+func renderToFile(ctx context.Context, tmpl *render.Template, resolver *secretref.Resolver, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmpl.Execute(ctx, resolver, tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -18,6 +20,8 @@ import (
 
 var cfgFile string
 
+var initConfigOnce sync.Once
+
 var tokenStorage token.Storage
 
 // RootCmd represents the base command when called without any subcommands
@@ -26,6 +30,23 @@ var rootCmd = &cobra.Command{
 	Short: "GophKeeper cli client",
 	Long:  "GophKeeper cli client allows keep and return secrets in/from Keeper server.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := applyProfile(cmd); err != nil {
+			slog.Error("Invalid profile: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		if err := unlockVault(cmd); err != nil {
+			slog.Error("Vault is locked: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		// Формат вывода проверяется до выполнения команды, чтобы не изменить
+		// секрет и не потерять результат
+		if _, err := outputFormat(cmd); err != nil {
+			slog.Error("Invalid output format: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
 		//const op = "rootCmd.PersistentPreRun"
 		//log := logger.GetInstance().Log.With("op", op)
 		//
@@ -74,6 +95,9 @@ var rootCmd = &cobra.Command{
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	preloadConfig()
+	registerTemplates()
+
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
@@ -81,7 +105,8 @@ func Execute() {
 }
 
 func init() {
-	tokenStorage = token.NewFileStorage("token.txt")
+	// token.txt в рабочем каталоге оставляли прежние версии клиента
+	tokenStorage = token.WithLegacyFile(token.NewEncryptedStorage(token.DefaultProfile), "token.txt")
 
 	rootCmd.PersistentFlags().StringVarP(
 		&cfgFile, "config", "c", "", "Client config filepath")
@@ -89,7 +114,23 @@ func init() {
 	cobra.OnInitialize(initConfig)
 }
 
+// preloadConfig читает конфигурацию до разбора команд, чтобы
+// пользовательские типы секретов успели превратиться в команды.
+func preloadConfig() {
+	flags := pflag.NewFlagSet("preload", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.SetOutput(io.Discard)
+	flags.StringVarP(&cfgFile, "config", "c", "", "")
+	_ = flags.Parse(os.Args[1:])
+
+	initConfig()
+}
+
 func initConfig() {
+	initConfigOnce.Do(readConfig)
+}
+
+func readConfig() {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/scrub"
+	"github.com/ajugalushkin/goph-keeper/client/internal/secretref"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run [--env NAME=keeper://secret/field]... -- command [args...]",
+	Short: "Run command with secrets in its environment",
+	Long: `Run a command with secrets injected into its environment.
+Secrets are passed only to the child process, they are not exported
+to the calling shell.
+
+References have the form keeper://<secret name>/<field>, for example
+keeper://prod-db/password. Environment variables of the current process
+whose values are references are resolved too.
+
+Signals are forwarded to the command, and the command's exit code is
+returned. With --scrub (or run.scrub in the config) secret values are
+replaced with ***** in the command's stdout and stderr.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "run"
+		// stdout принадлежит запускаемой команде
+		logger.UseStderr()
+		log := logger.GetInstance().Log.With("op", op)
+
+		envFlags, err := cmd.Flags().GetStringArray("env")
+		if err != nil {
+			log.Error("Error reading env flag: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		scrubOutput := config.GetInstance().Config.Run.Scrub
+		if cmd.Flags().Changed("scrub") {
+			if scrubOutput, err = cmd.Flags().GetBool("scrub"); err != nil {
+				log.Error("Error reading scrub flag: ", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}
+
+		env, refs, err := runEnvironment(os.Environ(), envFlags)
+		if err != nil {
+			log.Error("Invalid environment: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		token, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		conn, err := app.GetKeeperConnection(token)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		resolver := newSecretResolver(app.NewKeeperClient(conn))
+
+		parsed := make(map[string]secretref.Ref, len(refs))
+		for name, value := range refs {
+			ref, err := secretref.Parse(value)
+			if err != nil {
+				log.Error("Invalid secret reference: ",
+					slog.String("env", name),
+					slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+			parsed[name] = ref
+		}
+
+		ctx := context.Background()
+		prefetch := make([]secretref.Ref, 0, len(parsed))
+		for _, ref := range parsed {
+			prefetch = append(prefetch, ref)
+		}
+		if err := resolver.Prefetch(ctx, prefetch); err != nil {
+			log.Error("Failed to get secrets: ", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+
+		secrets := make([]string, 0, len(parsed))
+		for name, ref := range parsed {
+			value, err := resolver.Resolve(ctx, ref)
+			if err != nil {
+				log.Error("Failed to resolve secret: ",
+					slog.String("env", name),
+					slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+			env[name] = value
+			secrets = append(secrets, value)
+		}
+
+		os.Exit(runChild(log, args, env, secrets, scrubOutput))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringArray("env", nil, "Environment variable NAME=keeper://secret/field, can be repeated")
+	runCmd.Flags().Bool("scrub", false, "Replace secret values in the command output")
+}
+
+// runEnvironment собирает окружение дочернего процесса и отбирает
+// переменные, значения которых являются ссылками на секреты
+func runEnvironment(environ []string, envFlags []string) (env map[string]string, refs map[string]string, err error) {
+	env = make(map[string]string, len(environ)+len(envFlags))
+	refs = make(map[string]string)
+
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		env[name] = value
+		if secretref.IsRef(value) {
+			refs[name] = value
+		}
+	}
+
+	for _, kv := range envFlags {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			return nil, nil, fmt.Errorf("expected NAME=value, got %q", kv)
+		}
+		env[name] = value
+		delete(refs, name)
+		if secretref.IsRef(value) {
+			refs[name] = value
+		}
+	}
+	return env, refs, nil
+}
+
+// runChild запускает команду, пересылает ей сигналы и возвращает код ее завершения
+func runChild(log *slog.Logger, args []string, env map[string]string, secrets []string, scrubOutput bool) int {
+	child := exec.Command(args[0], args[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	for name, value := range env {
+		child.Env = append(child.Env, name+"="+value)
+	}
+
+	var scrubbers []*scrub.Writer
+	if scrubOutput {
+		stdout := scrub.NewWriter(os.Stdout, secrets)
+		stderr := scrub.NewWriter(os.Stderr, secrets)
+		child.Stdout, child.Stderr = stdout, stderr
+		scrubbers = append(scrubbers, stdout, stderr)
+	}
+
+	// Сигналы перехватываются до запуска, чтобы Ctrl+C не завершил
+	// клиент раньше дочернего процесса.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP,
+		syscall.SIGQUIT, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGWINCH)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		log.Error("Failed to start command: ", slog.String("error", err.Error()))
+		return 127
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				_ = child.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := child.Wait()
+	close(done)
+
+	for _, scrubber := range scrubbers {
+		_ = scrubber.Flush()
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr):
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal())
+		}
+		return exitErr.ExitCode()
+	default:
+		log.Error("Failed to run command: ", slog.String("error", err.Error()))
+		return 1
+	}
+}
+
+// newSecretResolver создает резолвер ссылок, загружающий секреты
+// пакетным запросом
+func newSecretResolver(keeperClient *app.KeeperClient) *secretref.Resolver {
+	return secretref.NewResolver(func(ctx context.Context, names []string) (map[string]vaulttypes.Vault, error) {
+		resp, err := keeperClient.BatchGetItems(ctx, &v1.BatchGetItemsRequestV1{Names: names})
+		if err != nil {
+			return nil, err
+		}
+
+		secrets := make(map[string]vaulttypes.Vault, len(resp.GetSecrets()))
+		for _, info := range resp.GetSecrets() {
+			secret, err := decryptSecret(info.GetContent())
+			if err != nil {
+				return nil, fmt.Errorf("secret %s: %w", info.GetName(), err)
+			}
+			secrets[info.GetName()] = secret
+		}
+		return secrets, nil
+	})
+}
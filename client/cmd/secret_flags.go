@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/prompt"
+)
+
+// stdinReader общий буфер stdin: несколько секретов читаются
+// из stdin по одному на строку в порядке флагов
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// addSecretFlag регистрирует флаг секретного значения и его варианты
+// --<name>-stdin и --<name>-fd для сценариев. Если prompted, то при
+// отсутствии всех трех флагов значение запрашивается в терминале.
+func addSecretFlag(cmd *cobra.Command, name, shorthand, usage string, prompted bool) {
+	help := usage + " (visible in shell history)"
+	if prompted {
+		help = usage + ", prompted if omitted (visible in shell history)"
+	}
+
+	flags := cmd.Flags()
+	flags.StringP(name, shorthand, "", help)
+	flags.Bool(name+"-stdin", false, "Read "+strings.ToLower(usage)+" from the first line of stdin")
+	flags.Int(name+"-fd", -1, "Read "+strings.ToLower(usage)+" from this file descriptor")
+	cmd.MarkFlagsMutuallyExclusive(name, name+"-stdin", name+"-fd")
+}
+
+// secretFlagSet проверяет, что значение передано одним из вариантов флага
+func secretFlagSet(cmd *cobra.Command, name string) bool {
+	flags := cmd.Flags()
+	return flags.Changed(name) || flags.Changed(name+"-stdin") || flags.Changed(name+"-fd")
+}
+
+// readSecretFlag возвращает значение секретного флага: из самого флага,
+// из stdin, из файлового дескриптора или из скрытого запроса в терминале.
+// Значение, переданное в командной строке, сопровождается предупреждением.
+func readSecretFlag(cmd *cobra.Command, name, label string, confirm bool) (string, error) {
+	flags := cmd.Flags()
+
+	if flags.Changed(name) {
+		fmt.Fprintf(os.Stderr,
+			"Warning: --%s is visible in shell history and the process list, omit it to be prompted or use --%s-stdin\n",
+			name, name)
+		return flags.GetString(name)
+	}
+
+	fromStdin, err := flags.GetBool(name + "-stdin")
+	if err != nil {
+		return "", err
+	}
+	if fromStdin {
+		return prompt.ReadLine(stdinReader)
+	}
+
+	fd, err := flags.GetInt(name + "-fd")
+	if err != nil {
+		return "", err
+	}
+	if fd >= 0 {
+		file := os.NewFile(uintptr(fd), name)
+		if file == nil {
+			return "", fmt.Errorf("invalid file descriptor %d", fd)
+		}
+		defer file.Close()
+		return prompt.ReadLine(bufio.NewReader(file))
+	}
+
+	var value string
+	if confirm {
+		value, err = prompt.SecretConfirm(label)
+	} else {
+		value, err = prompt.Secret(label)
+	}
+	if errors.Is(err, prompt.ErrNoTerminal) {
+		return "", fmt.Errorf("%s is required, use --%s-stdin or --%s-fd without a terminal",
+			strings.ToLower(label), name, name)
+	}
+	return value, err
+}
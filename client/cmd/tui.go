@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+	"github.com/ajugalushkin/goph-keeper/client/internal/app"
+	"github.com/ajugalushkin/goph-keeper/client/internal/clipboard"
+	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
+	"github.com/ajugalushkin/goph-keeper/client/internal/token"
+	"github.com/ajugalushkin/goph-keeper/client/internal/tui"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+	v1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+)
+
+// defaultAutoLock время бездействия до блокировки интерфейса по умолчанию
+const defaultAutoLock = 5 * time.Minute
+
+// tuiCmd represents the tui command
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and edit secrets in a terminal UI",
+	Long: `Full-screen terminal UI with a searchable list of secrets, a detail pane
+with sensitive fields masked until revealed, and forms to create and edit
+secrets of any type.
+
+After --autolock (tui.autolock in the config, 5m by default) without key
+presses the UI forgets decrypted secrets and asks for the account password.
+Use 0 to disable locking.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		const op = "tui"
+		log := logger.GetInstance().Log.With("op", op)
+
+		autoLock := config.GetInstance().Config.TUI.AutoLock
+		if autoLock == 0 || cmd.Flags().Changed("autolock") {
+			var err error
+			if autoLock, err = cmd.Flags().GetDuration("autolock"); err != nil {
+				log.Error("Error reading autolock flag: ", slog.String("error", err.Error()))
+				return
+			}
+		}
+
+		accessToken, err := tokenStorage.Load()
+		if err != nil {
+			log.Error("Failed to load access token: ", slog.String("error", err.Error()))
+			return
+		}
+		email, err := token.Email(accessToken)
+		if err != nil {
+			log.Debug("Unable to read account from token: ", slog.String("error", err.Error()))
+		}
+
+		opts := tui.Options{AutoLock: autoLock}
+		opts.Clipboard, opts.ClipboardErr = clipboard.New(
+			clipboard.Backend(config.GetInstance().Config.Clipboard.Backend))
+
+		conn, err := app.GetKeeperConnection(accessToken)
+		if err != nil {
+			log.Error("Failed to connect to server: ", slog.String("error", err.Error()))
+			return
+		}
+
+		store := &tuiStore{
+			keeperClient: app.NewKeeperClient(conn),
+			email:        email,
+		}
+		if err := tui.Run(store, opts); err != nil {
+			log.Error("Terminal UI failed: ", slog.String("error", err.Error()))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	tuiCmd.Flags().Duration("autolock", defaultAutoLock, "Lock after this idle time, 0 to disable")
+}
+
+// tuiStore источник секретов терминального интерфейса
+type tuiStore struct {
+	keeperClient *app.KeeperClient
+	email        string
+}
+
+func (s *tuiStore) List(ctx context.Context) ([]tui.Entry, error) {
+	secrets, err := listAllItems(ctx, s.keeperClient, &v1.ListItemsRequestV1{
+		SortBy:         v1.ListItemsRequestV1_SORT_BY_NAME,
+		IncludeContent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]tui.Entry, 0, len(secrets))
+	for _, info := range secrets {
+		secret, err := decryptSecret(info.GetContent())
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", info.GetName(), err)
+		}
+		values, err := vaulttypes.Values(secret)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", info.GetName(), err)
+		}
+		entries = append(entries, tui.Entry{
+			Name:    info.GetName(),
+			Version: info.GetVersion(),
+			Type:    secret.Type(),
+			Folder:  info.GetFolder(),
+			Tags:    info.GetTags(),
+			Values:  values,
+		})
+	}
+	return entries, nil
+}
+
+func (s *tuiStore) Create(ctx context.Context, name string, secret vaulttypes.Vault) error {
+	req, err := buildCreateItemRequest(name, "", nil, secret)
+	if err != nil {
+		return err
+	}
+	_, err = s.keeperClient.CreateItem(ctx, req)
+	return err
+}
+
+func (s *tuiStore) Update(ctx context.Context, entry tui.Entry, secret vaulttypes.Vault) error {
+	content, err := encryptSecret(secret)
+	if err != nil {
+		return err
+	}
+	_, err = s.keeperClient.UpdateItem(ctx, &v1.UpdateItemRequestV1{
+		Name:    entry.Name,
+		Content: content,
+		Version: entry.Version,
+	})
+	return err
+}
+
+// Unlock входит в учетную запись заново и сохраняет новый токен
+func (s *tuiStore) Unlock(ctx context.Context, password string) error {
+	if s.email == "" {
+		return errors.New("unknown account, log in with auth login")
+	}
+
+	authConn, err := app.GetAuthConnection()
+	if err != nil {
+		return err
+	}
+	accessToken, err := app.NewAuthClient(authConn).Login(ctx, s.email, password)
+	if err != nil {
+		return err
+	}
+	if err := tokenStorage.Save(accessToken); err != nil {
+		return err
+	}
+
+	conn, err := app.GetKeeperConnection(accessToken)
+	if err != nil {
+		return err
+	}
+	s.keeperClient = app.NewKeeperClient(conn)
+	return nil
+}
+
+func (s *tuiStore) Account() string {
+	return s.email
+}
@@ -12,12 +12,96 @@ type Client struct {
 	Address string        `yaml:"address" env-required:"true"`
 	Timeout time.Duration `yaml:"timeout" env-required:"true"`
 	Retries int           `yaml:"retries" env-required:"true"`
+	TLS     TLS           `yaml:"tls"`
+}
+
+// TLS параметры защищенного соединения с сервером
+type TLS struct {
+	Enabled bool `yaml:"enabled"`
+	// CA файл сертификата удостоверяющего центра, по умолчанию системные
+	CA string `yaml:"ca,omitempty"`
+	// ServerName имя сервера для проверки сертификата
+	ServerName string `yaml:"servername,omitempty"`
+	// Insecure отключает проверку сертификата сервера
+	Insecure bool `yaml:"insecure,omitempty"`
+}
+
+// Profile профиль сервера: адрес, параметры соединения и учетная запись
+type Profile struct {
+	Address string `yaml:"address"`
+	TLS     TLS    `yaml:"tls,omitempty"`
+	Account string `yaml:"account,omitempty"`
+}
+
+// TemplateField поле пользовательского типа секрета
+type TemplateField struct {
+	Name        string `yaml:"name"`
+	Sensitive   bool   `yaml:"sensitive"`
+	Required    bool   `yaml:"required"`
+	Description string `yaml:"description"`
+}
+
+// Template пользовательский тип секрета
+type Template struct {
+	Type   string          `yaml:"type"`
+	Fields []TemplateField `yaml:"fields"`
+}
+
+// Agent параметры агента SSH
+type Agent struct {
+	Socket  string `yaml:"socket"`
+	Confirm bool   `yaml:"confirm"`
+}
+
+// Run параметры запуска процессов с секретами
+type Run struct {
+	Scrub bool `yaml:"scrub"`
+}
+
+// Git параметры помощника учетных данных git
+type Git struct {
+	Name string `yaml:"name"`
+}
+
+// Docker параметры помощника учетных данных docker
+type Docker struct {
+	Prefix string `yaml:"prefix"`
+}
+
+// TUI параметры терминального интерфейса
+type TUI struct {
+	AutoLock time.Duration `yaml:"autolock"`
+}
+
+// Clipboard параметры буфера обмена
+type Clipboard struct {
+	Backend string        `yaml:"backend"`
+	Clear   time.Duration `yaml:"clear"`
+}
+
+// Session параметры агента сессии
+type Session struct {
+	AutoLock time.Duration `yaml:"autolock"`
+	// Require запрещает команды keep без разблокированной сессии
+	Require bool `yaml:"require"`
 }
 
 // Config структура параметров заауска.
 type Config struct {
-	Env    string `yaml:"env" env-required:"true"`
-	Client Client `yaml:"client" env-required:"true"`
+	Env       string     `yaml:"env" env-required:"true"`
+	Output    string     `yaml:"output"`
+	Client    Client     `yaml:"client" env-required:"true"`
+	Templates []Template `yaml:"templates"`
+	Agent     Agent      `yaml:"agent"`
+	Run       Run        `yaml:"run"`
+	Git       Git        `yaml:"git"`
+	Docker    Docker     `yaml:"docker"`
+	TUI       TUI        `yaml:"tui"`
+	Clipboard Clipboard  `yaml:"clipboard"`
+	Session   Session    `yaml:"session"`
+	// Profile профиль сервера по умолчанию
+	Profile  string             `yaml:"profile"`
+	Profiles map[string]Profile `yaml:"profiles"`
 }
 
 type CfgInstance struct {
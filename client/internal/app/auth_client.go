@@ -9,7 +9,6 @@ import (
 	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/ajugalushkin/goph-keeper/client/config"
 	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
@@ -54,8 +53,8 @@ func (c *AuthClient) Login(ctx context.Context, email string, password string) (
 	return resp.Token, nil
 }
 
-func GetAuthConnection() *grpc.ClientConn {
-	const op = "rootCmd.PersistentPreRun"
+func GetAuthConnection() (*grpc.ClientConn, error) {
+	const op = "app.GetAuthConnection"
 	log := logger.GetInstance().Log.With("op", op)
 
 	cfg := config.GetInstance().Config
@@ -69,19 +68,23 @@ func GetAuthConnection() *grpc.ClientConn {
 		grpclog.WithLogOnEvents(grpclog.PayloadReceived, grpclog.PayloadSent),
 	}
 
-	var err error
+	transport, err := transportCredentials(cfg.Client.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	connection, err := grpc.NewClient(cfg.Client.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transport),
 		grpc.WithChainUnaryInterceptor(
 			grpclog.UnaryClientInterceptor(interceptorLogger(log), logOpts...),
 			grpcretry.UnaryClientInterceptor(retryOpts...),
 		),
 	)
 	if err != nil {
-		slog.Error("Unable to connect to server", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return connection
+	return connection, nil
 }
 
 func interceptorLogger(l *slog.Logger) grpclog.Logger {
@@ -8,13 +8,12 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/ajugalushkin/goph-keeper/client/config"
-	"github.com/ajugalushkin/goph-keeper/client/internal/logger"
 	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 )
 
@@ -52,6 +51,72 @@ func (k *KeeperClient) GetItem(ctx context.Context, item *keeperv1.GetItemReques
 	return resp, nil
 }
 
+func (k *KeeperClient) UpdateItem(ctx context.Context, item *keeperv1.UpdateItemRequestV1) (*keeperv1.UpdateItemResponseV1, error) {
+	const op = "client.keeper.UpdateItem"
+
+	resp, err := k.api.UpdateItemV1(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (k *KeeperClient) RenameItem(ctx context.Context, item *keeperv1.RenameItemRequestV1) (*keeperv1.RenameItemResponseV1, error) {
+	const op = "client.keeper.RenameItem"
+
+	resp, err := k.api.RenameItemV1(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (k *KeeperClient) DeleteItem(ctx context.Context, item *keeperv1.DeleteItemRequestV1) (*keeperv1.DeleteItemResponseV1, error) {
+	const op = "client.keeper.DeleteItem"
+
+	resp, err := k.api.DeleteItemV1(ctx, item)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (k *KeeperClient) BatchCreateItems(ctx context.Context, items *keeperv1.BatchCreateItemsRequestV1) (*keeperv1.BatchItemsResponseV1, error) {
+	const op = "client.keeper.BatchCreateItems"
+
+	resp, err := k.api.BatchCreateItemsV1(ctx, items)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (k *KeeperClient) BatchUpdateItems(ctx context.Context, items *keeperv1.BatchUpdateItemsRequestV1) (*keeperv1.BatchItemsResponseV1, error) {
+	const op = "client.keeper.BatchUpdateItems"
+
+	resp, err := k.api.BatchUpdateItemsV1(ctx, items)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
+func (k *KeeperClient) BatchGetItems(ctx context.Context, items *keeperv1.BatchGetItemsRequestV1) (*keeperv1.BatchGetItemsResponseV1, error) {
+	const op = "client.keeper.BatchGetItems"
+
+	resp, err := k.api.BatchGetItemsV1(ctx, items)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return resp, nil
+}
+
 func (k *KeeperClient) CreateItemStream(log *slog.Logger, ctx context.Context, fileName string, filePath string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -137,27 +202,46 @@ func (k *KeeperClient) ListItems(ctx context.Context, item *keeperv1.ListItemsRe
 	return list, nil
 }
 
-func GetKeeperConnection(token string) *grpc.ClientConn {
+var (
+	keeperConnMu sync.Mutex
+	keeperConns  = make(map[string]*grpc.ClientConn)
+)
+
+// GetKeeperConnection возвращает соединение с сервером для токена.
+// Соединение создается один раз и переиспользуется всеми вызовами процесса.
+func GetKeeperConnection(token string) (*grpc.ClientConn, error) {
 	const op = "app.GetKeeperConnection"
-	log := logger.GetInstance().Log.With("op", op)
+
+	keeperConnMu.Lock()
+	defer keeperConnMu.Unlock()
+
+	if conn, ok := keeperConns[token]; ok {
+		return conn, nil
+	}
 
 	interceptor, err := NewAuthInterceptor(token, authMethods())
 	if err != nil {
-		log.Error("Unable to create interceptor: ", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	cfg := config.GetInstance().Config
+	transport, err := transportCredentials(cfg.Client.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	keeperClientConnection, err := grpc.NewClient(
 		cfg.Client.Address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(transport),
 		grpc.WithUnaryInterceptor(interceptor.Unary()),
 		grpc.WithStreamInterceptor(interceptor.Stream()),
 	)
 	if err != nil {
-		log.Error("Unable to connect to server: ", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return keeperClientConnection
+	keeperConns[token] = keeperClientConnection
+	return keeperClientConnection, nil
 }
 func authMethods() map[string]bool {
 	return map[string]bool{
@@ -165,5 +249,11 @@ func authMethods() map[string]bool {
 		keeperv1.KeeperServiceV1_GetItemV1_FullMethodName:          true,
 		keeperv1.KeeperServiceV1_CreateItemV1_FullMethodName:       true,
 		keeperv1.KeeperServiceV1_CreateItemStreamV1_FullMethodName: true,
+		keeperv1.KeeperServiceV1_UpdateItemV1_FullMethodName:       true,
+		keeperv1.KeeperServiceV1_BatchCreateItemsV1_FullMethodName: true,
+		keeperv1.KeeperServiceV1_BatchUpdateItemsV1_FullMethodName: true,
+		keeperv1.KeeperServiceV1_BatchGetItemsV1_FullMethodName:    true,
+		keeperv1.KeeperServiceV1_RenameItemV1_FullMethodName:       true,
+		keeperv1.KeeperServiceV1_DeleteItemV1_FullMethodName:       true,
 	}
 }
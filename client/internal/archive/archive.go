@@ -0,0 +1,294 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// FormatVersion версия формата содержимого архива
+const FormatVersion = 1
+
+// magic сигнатура зашифрованного архива
+var magic = []byte("GKVAULT\x00")
+
+var (
+	ErrNotArchive         = errors.New("file is not a goph-keeper archive")
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	ErrWrongPassword      = errors.New("wrong archive password or corrupted archive")
+	ErrInvalidHeader      = errors.New("invalid archive header")
+	ErrEmptyPassword      = errors.New("archive password is empty")
+)
+
+// Item секрет в архиве. Secret содержит секрет в открытом виде
+// в формате vaulttypes.EncodeVault, поэтому архив не зависит от ключей аккаунта.
+type Item struct {
+	Name      string          `json:"name"`
+	Type      string          `json:"type"`
+	Folder    string          `json:"folder,omitempty"`
+	Tags      []string        `json:"tags,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Secret    json.RawMessage `json:"secret"`
+}
+
+// Archive полная копия хранилища пользователя
+type Archive struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Items     []Item    `json:"items"`
+}
+
+// New создает архив текущей версии формата
+func New(items []Item) *Archive {
+	return &Archive{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Items:     items,
+	}
+}
+
+// header параметры шифрования, записываемые в открытом виде перед шифротекстом
+type header struct {
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Nonce   []byte `json:"nonce"`
+}
+
+const (
+	kdfArgon2id = "argon2id"
+	keyLen      = 32
+	saltLen     = 16
+)
+
+// Допустимые параметры Argon2id в заголовке. Заголовок читается до
+// проверки целостности, поэтому ограничения защищают от архивов,
+// требующих чрезмерных памяти и времени при выводе ключа.
+const (
+	minSaltLen = 16
+	maxSaltLen = 64
+	minTime    = 1
+	maxTime    = 10
+	minMemory  = 8 * 1024   // KiB
+	maxMemory  = 256 * 1024 // KiB
+	minThreads = 1
+	maxThreads = 16
+)
+
+// MarshalPlain возвращает архив в открытом виде в формате JSON
+func MarshalPlain(a *Archive) ([]byte, error) {
+	return json.MarshalIndent(a, "", "  ")
+}
+
+// Encrypt сериализует архив и шифрует его паролем.
+// Ключ выводится из пароля через Argon2id, содержимое шифруется AES-256-GCM,
+// заголовок с параметрами участвует в проверке целостности.
+func Encrypt(a *Archive, password []byte) ([]byte, error) {
+	const op = "archive.Encrypt"
+
+	if len(password) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrEmptyPassword)
+	}
+
+	payload, err := compress(a)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	h := header{
+		KDF:     kdfArgon2id,
+		Salt:    make([]byte, saltLen),
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+	if _, err := rand.Read(h.Salt); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	aead, err := newAEAD(h, password)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	h.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(h.Nonce); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	prefix, err := encodeHeader(h)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return aead.Seal(prefix, h.Nonce, payload, prefix), nil
+}
+
+// Read читает архив. Зашифрованный архив расшифровывается паролем,
+// архив в открытом виде читается как есть.
+func Read(data []byte, password []byte) (*Archive, error) {
+	const op = "archive.Read"
+
+	if !IsEncrypted(data) {
+		var a Archive
+		if err := json.Unmarshal(data, &a); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, ErrNotArchive)
+		}
+		if err := checkVersion(&a); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		return &a, nil
+	}
+
+	h, prefixLen, err := decodeHeader(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	aead, err := newAEAD(h, password)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(h.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%s: %w: nonce length %d", op, ErrInvalidHeader, len(h.Nonce))
+	}
+
+	payload, err := aead.Open(nil, h.Nonce, data[prefixLen:], data[:prefixLen])
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, ErrWrongPassword)
+	}
+
+	a, err := decompress(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := checkVersion(a); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return a, nil
+}
+
+// IsEncrypted проверяет, что данные являются зашифрованным архивом
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+func checkVersion(a *Archive) error {
+	if a.Version < 1 || a.Version > FormatVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, a.Version)
+	}
+	return nil
+}
+
+func newAEAD(h header, password []byte) (cipher.AEAD, error) {
+	if h.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("%w: unknown kdf %q", ErrUnsupportedVersion, h.KDF)
+	}
+	if err := checkKDFParams(h); err != nil {
+		return nil, err
+	}
+
+	key := argon2.IDKey(password, h.Salt, h.Time, h.Memory, h.Threads, keyLen)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// checkKDFParams проверяет параметры вывода ключа из заголовка
+func checkKDFParams(h header) error {
+	switch {
+	case len(h.Salt) < minSaltLen || len(h.Salt) > maxSaltLen:
+		return fmt.Errorf("%w: salt length %d", ErrInvalidHeader, len(h.Salt))
+	case h.Time < minTime || h.Time > maxTime:
+		return fmt.Errorf("%w: time %d", ErrInvalidHeader, h.Time)
+	case h.Memory < minMemory || h.Memory > maxMemory:
+		return fmt.Errorf("%w: memory %d KiB", ErrInvalidHeader, h.Memory)
+	case h.Threads < minThreads || h.Threads > maxThreads:
+		return fmt.Errorf("%w: threads %d", ErrInvalidHeader, h.Threads)
+	}
+	return nil
+}
+
+// encodeHeader записывает сигнатуру, версию контейнера и заголовок
+func encodeHeader(h header) ([]byte, error) {
+	encoded, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, 0, len(magic)+1+4+len(encoded))
+	prefix = append(prefix, magic...)
+	prefix = append(prefix, FormatVersion)
+	prefix = binary.BigEndian.AppendUint32(prefix, uint32(len(encoded)))
+	return append(prefix, encoded...), nil
+}
+
+func decodeHeader(data []byte) (header, int, error) {
+	var h header
+
+	offset := len(magic)
+	if len(data) < offset+1+4 {
+		return h, 0, ErrNotArchive
+	}
+	if version := data[offset]; version < 1 || version > FormatVersion {
+		return h, 0, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+	offset++
+
+	size := int(binary.BigEndian.Uint32(data[offset:]))
+	offset += 4
+	if size > len(data)-offset {
+		return h, 0, ErrNotArchive
+	}
+
+	if err := json.Unmarshal(data[offset:offset+size], &h); err != nil {
+		return h, 0, ErrNotArchive
+	}
+	return h, offset + size, nil
+}
+
+func compress(a *Archive) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(a); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(payload []byte) (*Archive, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var a Archive
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,193 @@
+package archive
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testArchive() *Archive {
+	created := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	return &Archive{
+		Version:   FormatVersion,
+		CreatedAt: created,
+		Items: []Item{
+			{
+				Name:      "prod-db",
+				Type:      "credentials",
+				Folder:    "work",
+				Tags:      []string{"db", "prod"},
+				CreatedAt: created,
+				UpdatedAt: created,
+				Secret:    json.RawMessage(`{"login":"admin","password":"s3cret"}`),
+			},
+			{
+				Name:      "note",
+				Type:      "text",
+				CreatedAt: created,
+				UpdatedAt: created,
+				Secret:    json.RawMessage(`{"text":"hello"}`),
+			},
+		},
+	}
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	a := testArchive()
+
+	data, err := Encrypt(a, []byte("password"))
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(data))
+	assert.NotContains(t, string(data), "s3cret")
+
+	got, err := Read(data, []byte("password"))
+	require.NoError(t, err)
+	assert.Equal(t, a, got)
+}
+
+func TestArchive_PlainRoundTrip(t *testing.T) {
+	a := testArchive()
+
+	data, err := MarshalPlain(a)
+	require.NoError(t, err)
+	assert.False(t, IsEncrypted(data))
+
+	got, err := Read(data, nil)
+	require.NoError(t, err)
+	require.Len(t, got.Items, len(a.Items))
+
+	// MarshalPlain добавляет отступы и в содержимое секретов
+	for i := range got.Items {
+		assert.JSONEq(t, string(a.Items[i].Secret), string(got.Items[i].Secret))
+		got.Items[i].Secret = a.Items[i].Secret
+	}
+	assert.Equal(t, a, got)
+}
+
+func TestEncrypt_EmptyPassword(t *testing.T) {
+	_, err := Encrypt(testArchive(), nil)
+	require.ErrorIs(t, err, ErrEmptyPassword)
+}
+
+func TestRead_FailCases(t *testing.T) {
+	encrypted, err := Encrypt(testArchive(), []byte("password"))
+	require.NoError(t, err)
+
+	tampered := append([]byte(nil), encrypted...)
+	tampered[len(tampered)-1] ^= 0xff
+
+	unsupported, err := MarshalPlain(&Archive{Version: FormatVersion + 1})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		password    string
+		expectedErr error
+	}{
+		{
+			name:        "Wrong password",
+			data:        encrypted,
+			password:    "wrong",
+			expectedErr: ErrWrongPassword,
+		},
+		{
+			name:        "Tampered ciphertext",
+			data:        tampered,
+			password:    "password",
+			expectedErr: ErrWrongPassword,
+		},
+		{
+			name:        "Truncated header",
+			data:        encrypted[:len(magic)+2],
+			password:    "password",
+			expectedErr: ErrNotArchive,
+		},
+		{
+			name:        "Not an archive",
+			data:        []byte("hello"),
+			expectedErr: ErrNotArchive,
+		},
+		{
+			name:        "Unsupported version",
+			data:        unsupported,
+			expectedErr: ErrUnsupportedVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(tt.data, []byte(tt.password))
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestRead_InvalidHeader(t *testing.T) {
+	valid := header{
+		KDF:     kdfArgon2id,
+		Salt:    make([]byte, saltLen),
+		Time:    1,
+		Memory:  minMemory,
+		Threads: 1,
+		Nonce:   make([]byte, 12),
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(h *header)
+		expectedErr error
+	}{
+		{
+			name:        "Zero threads",
+			modify:      func(h *header) { h.Threads = 0 },
+			expectedErr: ErrInvalidHeader,
+		},
+		{
+			name:        "Too many threads",
+			modify:      func(h *header) { h.Threads = maxThreads + 1 },
+			expectedErr: ErrInvalidHeader,
+		},
+		{
+			name:        "Huge memory",
+			modify:      func(h *header) { h.Memory = 4 * 1024 * 1024 },
+			expectedErr: ErrInvalidHeader,
+		},
+		{
+			name:        "Zero time",
+			modify:      func(h *header) { h.Time = 0 },
+			expectedErr: ErrInvalidHeader,
+		},
+		{
+			name:        "Short salt",
+			modify:      func(h *header) { h.Salt = make([]byte, 4) },
+			expectedErr: ErrInvalidHeader,
+		},
+		{
+			name:        "Wrong nonce length",
+			modify:      func(h *header) { h.Nonce = make([]byte, 4) },
+			expectedErr: ErrInvalidHeader,
+		},
+		{
+			name:        "Unknown kdf",
+			modify:      func(h *header) { h.KDF = "scrypt" },
+			expectedErr: ErrUnsupportedVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := valid
+			tt.modify(&h)
+
+			prefix, err := encodeHeader(h)
+			require.NoError(t, err)
+
+			_, err = Read(append(prefix, make([]byte, 32)...), []byte("password"))
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
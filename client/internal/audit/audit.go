@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"sort"
+	"time"
+)
+
+// Item учетные данные, подготовленные к проверке
+type Item struct {
+	Name      string
+	Login     string
+	Password  string
+	URL       string
+	UpdatedAt time.Time
+}
+
+// Options параметры проверки
+type Options struct {
+	// MinScore минимальная допустимая оценка стойкости пароля
+	MinScore int
+	// MaxAge максимальный возраст пароля, 0 отключает проверку
+	MaxAge time.Duration
+	// Now момент, относительно которого считается возраст
+	Now time.Time
+	// Breached возвращает, сколько раз пароль встречался в утечках.
+	// Если не задана, проверка по утечкам не выполняется.
+	Breached func(password string) (uint32, error)
+}
+
+// ItemReport результат проверки одного секрета
+type ItemReport struct {
+	Name       string    `json:"name"`
+	Strength   Estimate  `json:"strength"`
+	Weak       bool      `json:"weak"`
+	ReusedWith []string  `json:"reused_with,omitempty"`
+	Old        bool      `json:"old"`
+	Breached   uint32    `json:"breached,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	AgeDays    int       `json:"age_days"`
+}
+
+// Summary сводка по хранилищу
+type Summary struct {
+	Total  int `json:"total"`
+	Weak   int `json:"weak"`
+	Reused int `json:"reused"`
+	Old    int `json:"old"`
+	// Breached число паролей, найденных в утечках, -1 если проверка не выполнялась
+	Breached int `json:"breached"`
+}
+
+// Report отчет о качестве паролей
+type Report struct {
+	Summary Summary      `json:"summary"`
+	Items   []ItemReport `json:"items"`
+}
+
+// Run проверяет пароли: оценивает стойкость, находит пароли, использованные
+// в нескольких секретах, пароли, которые давно не менялись, и пароли из утечек.
+func Run(items []Item, opts Options) (Report, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	byPassword := make(map[string][]string, len(items))
+	for _, item := range items {
+		if item.Password != "" {
+			byPassword[item.Password] = append(byPassword[item.Password], item.Name)
+		}
+	}
+
+	report := Report{Items: make([]ItemReport, 0, len(items))}
+	if opts.Breached == nil {
+		report.Summary.Breached = -1
+	}
+	for _, item := range items {
+		result := ItemReport{
+			Name:      item.Name,
+			Strength:  EstimateStrength(item.Password, []string{item.Login, item.URL, item.Name}),
+			UpdatedAt: item.UpdatedAt,
+			AgeDays:   int(opts.Now.Sub(item.UpdatedAt).Hours() / 24),
+		}
+		result.Weak = result.Strength.Score < opts.MinScore
+
+		for _, name := range byPassword[item.Password] {
+			if name != item.Name {
+				result.ReusedWith = append(result.ReusedWith, name)
+			}
+		}
+
+		if opts.MaxAge > 0 && !item.UpdatedAt.IsZero() {
+			result.Old = opts.Now.Sub(item.UpdatedAt) > opts.MaxAge
+		}
+
+		if opts.Breached != nil && item.Password != "" {
+			breached, err := opts.Breached(item.Password)
+			if err != nil {
+				return Report{}, err
+			}
+			result.Breached = breached
+			if breached > 0 {
+				report.Summary.Breached++
+			}
+		}
+
+		report.Summary.Total++
+		if result.Weak {
+			report.Summary.Weak++
+		}
+		if len(result.ReusedWith) > 0 {
+			report.Summary.Reused++
+		}
+		if result.Old {
+			report.Summary.Old++
+		}
+		report.Items = append(report.Items, result)
+	}
+
+	// Проблемные секреты выводятся первыми: утекшие, затем самые слабые.
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		a, b := report.Items[i], report.Items[j]
+		if (a.Breached > 0) != (b.Breached > 0) {
+			return a.Breached > 0
+		}
+		if a.Strength.Score != b.Strength.Score {
+			return a.Strength.Score < b.Strength.Score
+		}
+		if len(a.ReusedWith) != len(b.ReusedWith) {
+			return len(a.ReusedWith) > len(b.ReusedWith)
+		}
+		return a.Name < b.Name
+	})
+	return report, nil
+}
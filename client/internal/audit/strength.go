@@ -0,0 +1,401 @@
+package audit
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/generator"
+)
+
+//go:embed passwords.txt
+var passwordsData string
+
+// dictionaries словари с рангами слов: чем меньше ранг, тем раньше слово
+// проверит атакующий
+var dictionaries = map[string]map[string]int{
+	"passwords": rankedList(strings.Fields(passwordsData)),
+	"words":     rankedList(generator.Words()),
+}
+
+// keyboardRows ряды клавиатуры для поиска соседних клавиш
+var keyboardRows = []string{
+	"1234567890-=",
+	"qwertyuiop[]",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+// l33t замены символов на похожие
+var l33t = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '3': 'e', '6': 'g', '1': 'i', '!': 'i',
+	'0': 'o', '5': 's', '$': 's', '7': 't', '+': 't', '2': 'z',
+}
+
+// Пороги оценки по десятичному логарифму числа попыток, как в zxcvbn
+const (
+	scoreTooGuessable      = 3
+	scoreVeryGuessable     = 6
+	scoreSomewhatGuessable = 8
+	scoreSafelyUnguessable = 10
+
+	minYearSpace = 20
+
+	// bruteforceCardinality число попыток на символ вне шаблонов, как в zxcvbn
+	bruteforceCardinality = 10
+)
+
+// Estimate оценка стойкости пароля
+type Estimate struct {
+	// Score оценка от 0 (угадывается сразу) до 4 (очень стойкий)
+	Score int `json:"score"`
+	// Guesses десятичный логарифм числа попыток подбора
+	Guesses float64 `json:"guesses_log10"`
+	// Warning описание самого слабого места пароля
+	Warning string `json:"warning,omitempty"`
+}
+
+// match найденный в пароле шаблон
+type match struct {
+	i, j    int
+	guesses float64 // log10
+	warning string
+}
+
+// EstimateStrength оценивает, сколько попыток нужно, чтобы подобрать пароль.
+// Как и zxcvbn, пароль разбивается на шаблоны (словарные слова, последовательности,
+// повторы, ряды клавиатуры, годы), и выбирается разбиение с наименьшим числом попыток.
+// userInputs — данные, которые атакующий знает заранее: логин, адрес сайта, имя секрета.
+func EstimateStrength(password string, userInputs []string) Estimate {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return Estimate{Warning: "password is empty"}
+	}
+
+	matches := findMatches(runes, userInputs)
+
+	// best[k] — наименьшее число попыток для префикса длины k,
+	// через перебор отдельных символов или через найденные шаблоны.
+	bruteforce := math.Log10(bruteforceCardinality)
+	best := make([]float64, len(runes)+1)
+	via := make([]*match, len(runes)+1)
+	for k := 1; k <= len(runes); k++ {
+		best[k] = best[k-1] + bruteforce
+		via[k] = nil
+		for m := range matches {
+			if matches[m].j+1 != k {
+				continue
+			}
+			if guesses := best[matches[m].i] + matches[m].guesses; guesses < best[k] {
+				best[k] = guesses
+				via[k] = &matches[m]
+			}
+		}
+	}
+
+	estimate := Estimate{Guesses: best[len(runes)]}
+	weakest := math.Inf(1)
+	for k := len(runes); k > 0; {
+		if via[k] == nil {
+			k--
+			continue
+		}
+		if via[k].guesses < weakest {
+			weakest = via[k].guesses
+			estimate.Warning = via[k].warning
+		}
+		k = via[k].i
+	}
+
+	switch {
+	case estimate.Guesses < scoreTooGuessable:
+		estimate.Score = 0
+	case estimate.Guesses < scoreVeryGuessable:
+		estimate.Score = 1
+	case estimate.Guesses < scoreSomewhatGuessable:
+		estimate.Score = 2
+	case estimate.Guesses < scoreSafelyUnguessable:
+		estimate.Score = 3
+	default:
+		estimate.Score = 4
+	}
+	if estimate.Score < 3 && estimate.Warning == "" {
+		estimate.Warning = "password is too short"
+	}
+	return estimate
+}
+
+func findMatches(runes []rune, userInputs []string) []match {
+	matches := make([]match, 0)
+	matches = append(matches, dictionaryMatches(runes, userInputs)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+	return matches
+}
+
+// dictionaryMatches ищет словарные слова, в том числе записанные
+// задом наперед, с заглавными буквами и заменами l33t
+func dictionaryMatches(runes []rune, userInputs []string) []match {
+	inputs := make([]string, 0, len(userInputs))
+	for _, input := range userInputs {
+		if input = strings.ToLower(input); len(input) >= 3 {
+			inputs = append(inputs, input)
+		}
+	}
+	dicts := make(map[string]map[string]int, len(dictionaries)+1)
+	for name, dict := range dictionaries {
+		dicts[name] = dict
+	}
+	dicts["user inputs"] = rankedList(inputs)
+
+	lower := []rune(strings.ToLower(string(runes)))
+	unleet := make([]rune, len(lower))
+	for i, r := range lower {
+		if sub, ok := l33t[r]; ok {
+			unleet[i] = sub
+		} else {
+			unleet[i] = r
+		}
+	}
+
+	matches := make([]match, 0)
+	for i := range lower {
+		for j := i + 2; j < len(lower); j++ {
+			candidates := []struct {
+				word       string
+				multiplier float64
+			}{
+				{string(lower[i : j+1]), 1},
+				{reverse(string(lower[i : j+1])), 2},
+				{string(unleet[i : j+1]), 2},
+			}
+
+			for n, candidate := range candidates {
+				if n == 2 && candidate.word == string(lower[i:j+1]) {
+					continue
+				}
+				for name, dict := range dicts {
+					rank, ok := dict[candidate.word]
+					if !ok {
+						continue
+					}
+					guesses := float64(rank) * candidate.multiplier * uppercaseVariations(runes[i:j+1])
+					matches = append(matches, match{
+						i:       i,
+						j:       j,
+						guesses: math.Log10(math.Max(guesses, 10)),
+						warning: dictionaryWarning(name),
+					})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+func dictionaryWarning(name string) string {
+	switch name {
+	case "passwords":
+		return "contains a commonly used password"
+	case "user inputs":
+		return "contains the login, site or secret name"
+	default:
+		return "contains a dictionary word"
+	}
+}
+
+// uppercaseVariations учитывает заглавные буквы: первая заглавная почти
+// не усложняет подбор, произвольные заглавные усложняют сильнее
+func uppercaseVariations(word []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 || (lower == 0 && upper == len(word)) {
+		if upper == 0 {
+			return 1
+		}
+		return 2
+	}
+	if upper == 1 && unicode.IsUpper(word[0]) {
+		return 2
+	}
+	return math.Pow(2, math.Min(float64(upper), float64(lower)))
+}
+
+// sequenceMatches ищет последовательности вида abcd, 9876
+func sequenceMatches(runes []rune) []match {
+	matches := make([]match, 0)
+	for i := 0; i < len(runes)-2; {
+		delta := runes[i+1] - runes[i]
+		if delta != 1 && delta != -1 {
+			i++
+			continue
+		}
+
+		j := i + 1
+		for j+1 < len(runes) && runes[j+1]-runes[j] == delta {
+			j++
+		}
+		if j-i+1 >= 3 {
+			base := 26.0
+			switch first := unicode.ToLower(runes[i]); {
+			case strings.ContainsRune("a1z9", first):
+				base = 4
+			case unicode.IsDigit(first):
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, match{
+				i:       i,
+				j:       j,
+				guesses: math.Log10(base * float64(j-i+1)),
+				warning: "contains a sequence like abc or 6543",
+			})
+		}
+		i = j
+	}
+	return matches
+}
+
+// repeatMatches ищет повторы одного символа
+func repeatMatches(runes []rune) []match {
+	matches := make([]match, 0)
+	for i := 0; i < len(runes); {
+		j := i
+		for j+1 < len(runes) && runes[j+1] == runes[i] {
+			j++
+		}
+		if j-i+1 >= 3 {
+			matches = append(matches, match{
+				i:       i,
+				j:       j,
+				guesses: math.Log10(float64(cardinality(runes[i:i+1])) * float64(j-i+1)),
+				warning: "contains repeated characters like aaa",
+			})
+		}
+		i = j + 1
+	}
+	return matches
+}
+
+// keyboardMatches ищет подряд идущие клавиши одного ряда клавиатуры
+func keyboardMatches(runes []rune) []match {
+	lower := []rune(strings.ToLower(string(runes)))
+	matches := make([]match, 0)
+	for i := 0; i < len(lower)-2; {
+		j := i
+		for j+1 < len(lower) && adjacentKeys(lower[j], lower[j+1]) {
+			j++
+		}
+		if j-i+1 >= 3 {
+			matches = append(matches, match{
+				i:       i,
+				j:       j,
+				guesses: math.Log10(float64(len(strings.Join(keyboardRows, ""))) * 2 * float64(j-i+1)),
+				warning: "contains a keyboard pattern like qwerty",
+			})
+			i = j
+			continue
+		}
+		i++
+	}
+	return matches
+}
+
+func adjacentKeys(a, b rune) bool {
+	for _, row := range keyboardRows {
+		ia, ib := strings.IndexRune(row, a), strings.IndexRune(row, b)
+		if ia >= 0 && ib >= 0 && (ia-ib == 1 || ib-ia == 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// yearMatches ищет годы 1900-2099, которые часто добавляют к паролям
+func yearMatches(runes []rune) []match {
+	current := time.Now().Year()
+	matches := make([]match, 0)
+	for i := 0; i+4 <= len(runes); i++ {
+		year := 0
+		for _, r := range runes[i : i+4] {
+			if !unicode.IsDigit(r) {
+				year = -1
+				break
+			}
+			year = year*10 + int(r-'0')
+		}
+		if year < 1900 || year > 2099 {
+			continue
+		}
+
+		space := max(current-year, year-current, minYearSpace)
+		matches = append(matches, match{
+			i:       i,
+			j:       i + 3,
+			guesses: math.Log10(float64(space)),
+			warning: "contains a year",
+		})
+	}
+	return matches
+}
+
+// cardinality размер алфавита, из которого составлен пароль
+func cardinality(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	for _, class := range []struct {
+		present bool
+		size    int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100}} {
+		if class.present {
+			size += class.size
+		}
+	}
+	return size
+}
+
+func rankedList(words []string) map[string]int {
+	ranked := make(map[string]int, len(words))
+	for i, word := range words {
+		if _, ok := ranked[word]; !ok {
+			ranked[word] = i + 1
+		}
+	}
+	return ranked
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
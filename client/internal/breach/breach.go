@@ -0,0 +1,249 @@
+package breach
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Формат индекса:
+//
+//	magic (8 байт) | число записей (uint64) | fanout (65536 x uint64) | записи
+//
+// Записи отсортированы по хэшу. fanout[p] — число записей, у которых первые
+// два байта SHA-1 меньше или равны p. Запись хранит байты 2..9 хэша
+// и число утечек (uint32), поэтому занимает 12 байт вместо 20 байт хэша
+// и строки в исходном наборе. Вероятность ложного совпадения по 80 битам
+// хэша пренебрежимо мала.
+const (
+	fanoutSize  = 1 << 16
+	suffixSize  = 8
+	recordSize  = suffixSize + 4
+	headerSize  = 8 + 8 + fanoutSize*8
+	hashHexSize = sha1.Size * 2
+)
+
+var magic = []byte("GKBRIDX1")
+
+var (
+	ErrNotIndex  = errors.New("file is not a breach index")
+	ErrUnsorted  = errors.New("dataset is not sorted by hash")
+	ErrMalformed = errors.New("malformed dataset line")
+)
+
+// DefaultPath возвращает путь к индексу в каталоге кэша пользователя
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goph-keeper", "breach.idx"), nil
+}
+
+// Build строит индекс из набора данных в формате HIBP Pwned Passwords:
+// строки вида SHA1:COUNT, отсортированные по хэшу. Одинаковые хэши
+// объединяются. Возвращает число записей индекса.
+func Build(dataset io.Reader, index io.WriteSeeker) (int, error) {
+	const op = "breach.Build"
+
+	if _, err := index.Seek(headerSize, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	writer := bufio.NewWriter(index)
+
+	var (
+		fanout  [fanoutSize]uint64
+		count   uint64
+		prev    []byte
+		pending uint64
+		record  [recordSize]byte
+	)
+	flush := func() error {
+		if prev == nil {
+			return nil
+		}
+		copy(record[:suffixSize], prev[2:2+suffixSize])
+		binary.BigEndian.PutUint32(record[suffixSize:], uint32(min(pending, 1<<32-1)))
+		if _, err := writer.Write(record[:]); err != nil {
+			return err
+		}
+		fanout[binary.BigEndian.Uint16(prev)]++
+		count++
+		return nil
+	}
+
+	scanner := bufio.NewScanner(dataset)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		hash, occurrences, err := parseLine(text)
+		if err != nil {
+			return 0, fmt.Errorf("%s: line %d: %w", op, line, err)
+		}
+
+		switch cmp := bytes.Compare(hash, prev); {
+		case prev != nil && cmp < 0:
+			return 0, fmt.Errorf("%s: line %d: %w", op, line, ErrUnsorted)
+		case prev != nil && cmp == 0:
+			pending += occurrences
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+		prev, pending = hash, occurrences
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := flush(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := writer.Flush(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = binary.BigEndian.AppendUint64(header, count)
+	var total uint64
+	for _, n := range fanout {
+		total += n
+		header = binary.BigEndian.AppendUint64(header, total)
+	}
+
+	if _, err := index.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err := index.Write(header); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return int(count), nil
+}
+
+// parseLine разбирает строку HASH[:COUNT]
+func parseLine(text string) ([]byte, uint64, error) {
+	hashText, countText, hasCount := strings.Cut(text, ":")
+	if len(hashText) != hashHexSize {
+		return nil, 0, ErrMalformed
+	}
+
+	hash, err := hex.DecodeString(hashText)
+	if err != nil {
+		return nil, 0, ErrMalformed
+	}
+
+	occurrences := uint64(1)
+	if hasCount {
+		if occurrences, err = strconv.ParseUint(strings.TrimSpace(countText), 10, 64); err != nil {
+			return nil, 0, ErrMalformed
+		}
+	}
+	return hash, occurrences, nil
+}
+
+// Index индекс утекших паролей на диске. Поиск читает только нужный
+// диапазон записей и не загружает индекс в память.
+type Index struct {
+	file  *os.File
+	count uint64
+}
+
+// Open открывает индекс
+func Open(path string) (*Index, error) {
+	const op = "breach.Open"
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	header := make([]byte, 16)
+	if _, err := file.ReadAt(header, 0); err != nil || !bytes.Equal(header[:8], magic) {
+		file.Close()
+		return nil, fmt.Errorf("%s: %w", op, ErrNotIndex)
+	}
+
+	return &Index{
+		file:  file,
+		count: binary.BigEndian.Uint64(header[8:]),
+	}, nil
+}
+
+// Len возвращает число хэшей в индексе
+func (i *Index) Len() int {
+	return int(i.count)
+}
+
+// Close закрывает файл индекса
+func (i *Index) Close() error {
+	return i.file.Close()
+}
+
+// Lookup проверяет пароль по индексу. Хэш вычисляется локально,
+// пароль никуда не передается. Возвращает число утечек, 0 — пароль не найден.
+func (i *Index) Lookup(password string) (uint32, error) {
+	hash := sha1.Sum([]byte(password))
+	return i.LookupHash(hash)
+}
+
+// LookupHash ищет SHA-1 хэш пароля в индексе
+func (i *Index) LookupHash(hash [sha1.Size]byte) (uint32, error) {
+	const op = "breach.Lookup"
+
+	prefix := int64(binary.BigEndian.Uint16(hash[:2]))
+	lo, err := i.fanout(prefix - 1)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	hi, err := i.fanout(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	want := hash[2 : 2+suffixSize]
+	record := make([]byte, recordSize)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if _, err := i.file.ReadAt(record, headerSize+int64(mid)*recordSize); err != nil {
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		switch bytes.Compare(record[:suffixSize], want) {
+		case 0:
+			return binary.BigEndian.Uint32(record[suffixSize:]), nil
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, nil
+}
+
+// fanout возвращает число записей с префиксом не больше p
+func (i *Index) fanout(p int64) (uint64, error) {
+	if p < 0 {
+		return 0, nil
+	}
+	buf := make([]byte, 8)
+	if _, err := i.file.ReadAt(buf, 16+p*8); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf), nil
+}
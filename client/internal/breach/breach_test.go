@@ -0,0 +1,115 @@
+package breach
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Hex(password string) string {
+	hash := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+// buildIndex строит индекс из строк набора данных во временном каталоге
+func buildIndex(t *testing.T, lines []string) (string, int, error) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "breach.idx")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	count, err := Build(strings.NewReader(strings.Join(lines, "\n")), file)
+	return path, count, err
+}
+
+func TestIndex_Lookup(t *testing.T) {
+	lines := []string{
+		sha1Hex("password") + ":3861493",
+		sha1Hex("123456") + ":37359195",
+		sha1Hex("qwerty") + ":10",
+		sha1Hex("qwerty") + ":5",
+		sha1Hex("letmein"),
+	}
+	sort.Strings(lines)
+
+	path, count, err := buildIndex(t, lines)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	index, err := Open(path)
+	require.NoError(t, err)
+	defer index.Close()
+	assert.Equal(t, 4, index.Len())
+
+	tests := []struct {
+		password string
+		expected uint32
+	}{
+		{password: "password", expected: 3861493},
+		{password: "123456", expected: 37359195},
+		{password: "qwerty", expected: 15},
+		{password: "letmein", expected: 1},
+		{password: "correct horse battery staple", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			occurrences, err := index.Lookup(tt.password)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, occurrences)
+		})
+	}
+}
+
+func TestBuild_FailCases(t *testing.T) {
+	tests := []struct {
+		name        string
+		lines       []string
+		expectedErr error
+	}{
+		{
+			name:        "Unsorted",
+			lines:       []string{strings.Repeat("F", 40) + ":1", strings.Repeat("0", 40) + ":1"},
+			expectedErr: ErrUnsorted,
+		},
+		{
+			name:        "Short hash",
+			lines:       []string{"ABCDEF:1"},
+			expectedErr: ErrMalformed,
+		},
+		{
+			name:        "Not hex",
+			lines:       []string{strings.Repeat("Z", 40) + ":1"},
+			expectedErr: ErrMalformed,
+		},
+		{
+			name:        "Invalid count",
+			lines:       []string{strings.Repeat("0", 40) + ":many"},
+			expectedErr: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := buildIndex(t, tt.lines)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestOpen_NotIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breach.idx")
+	require.NoError(t, os.WriteFile(path, []byte("not an index file"), 0o600))
+
+	_, err := Open(path)
+	require.ErrorIs(t, err, ErrNotIndex)
+}
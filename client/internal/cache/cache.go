@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Version версия формата содержимого кэша
+const Version = 2
+
+const (
+	// fileName имя файла кэша
+	fileName = "vault.cache"
+	// legacyFileName файл кэша прежних версий, хранившийся открытым текстом
+	legacyFileName = "vault.json"
+	// fileMagic заголовок зашифрованного файла кэша
+	fileMagic = "GKC1"
+)
+
+// additionalData связывает шифротекст с назначением файла
+var additionalData = []byte("goph-keeper vault cache")
+
+// Item запись локального кэша. Кэш хранит только метаданные секрета и
+// несекретные поля, по которым выполняется поиск: пароли, номера карт
+// и другие чувствительные поля в кэш не попадают. Заметки и текст
+// секретов попадают, поэтому файл кэша шифруется.
+type Item struct {
+	ID        string    `json:"id,omitempty"`
+	Name      string    `json:"name"`
+	Version   string    `json:"version"`
+	Type      string    `json:"type"`
+	Folder    string    `json:"folder,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Fields несекретные поля секрета по имени поля схемы
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// Cache локальная копия хранилища пользователя
+type Cache struct {
+	path     string
+	aead     cipher.AEAD
+	Version  int             `json:"version"`
+	SyncedAt time.Time       `json:"synced_at"`
+	Items    map[string]Item `json:"items"`
+}
+
+// DefaultPath возвращает путь к файлу кэша в каталоге кэша пользователя
+func DefaultPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goph-keeper", fileName), nil
+}
+
+// Open читает кэш из файла, зашифрованного ключом key (AES-256-GCM).
+// Отсутствующий файл означает пустой кэш. Кэш восстанавливается
+// синхронизацией, поэтому файл, который не удалось расшифровать или
+// прочитать, удаляется. Удаляется и файл кэша прежних версий рядом с path,
+// так как он хранился открытым текстом.
+func Open(path string, key []byte) (*Cache, error) {
+	const op = "cache.Open"
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	legacy := filepath.Join(filepath.Dir(path), legacyFileName)
+	if err := os.Remove(legacy); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	c := &Cache{
+		path:    path,
+		aead:    aead,
+		Version: Version,
+		Items:   make(map[string]Item),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := c.decode(data); err != nil {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		c.Version = Version
+		c.SyncedAt = time.Time{}
+		c.Items = make(map[string]Item)
+		return c, nil
+	}
+	if c.Items == nil {
+		c.Items = make(map[string]Item)
+	}
+	return c, nil
+}
+
+// decode расшифровывает содержимое файла кэша
+func (c *Cache) decode(data []byte) error {
+	nonceSize := c.aead.NonceSize()
+	if !bytes.HasPrefix(data, []byte(fileMagic)) || len(data) < len(fileMagic)+nonceSize {
+		return errors.New("not a cache file")
+	}
+	data = data[len(fileMagic):]
+
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], additionalData)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(plaintext, c); err != nil {
+		return err
+	}
+	if c.Version != Version {
+		return fmt.Errorf("unsupported cache version %d", c.Version)
+	}
+	return nil
+}
+
+// Replace заменяет содержимое кэша результатом полной синхронизации
+func (c *Cache) Replace(items []Item) {
+	c.Items = make(map[string]Item, len(items))
+	for _, item := range items {
+		c.Items[item.Name] = item
+	}
+	c.Version = Version
+	c.SyncedAt = time.Now()
+}
+
+// List возвращает записи кэша, отсортированные по имени
+func (c *Cache) List() []Item {
+	items := make([]Item, 0, len(c.Items))
+	for _, item := range c.Items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+// Save шифрует кэш и атомарно записывает его на диск с правами только
+// для владельца
+func (c *Cache) Save() error {
+	const op = "cache.Save"
+
+	plaintext, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	data := append([]byte(fileMagic), nonce...)
+	data = c.aead.Seal(data, nonce, plaintext, additionalData)
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), ".vault-*.tmp")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
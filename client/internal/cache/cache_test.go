@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func testItems() []Item {
+	created := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	return []Item{
+		{
+			Name:      "prod-db",
+			Version:   "v1",
+			Type:      "credentials",
+			Folder:    "work",
+			Tags:      []string{"db"},
+			CreatedAt: created,
+			UpdatedAt: created,
+			Fields:    map[string]string{"login": "admin", "notes": "root access via bastion"},
+		},
+		{
+			Name:      "diary",
+			Version:   "v2",
+			Type:      "text",
+			CreatedAt: created,
+			UpdatedAt: created,
+			Fields:    map[string]string{"data": "private diary entry"},
+		},
+	}
+}
+
+func TestCache_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileName)
+
+	c, err := Open(path, testKey(1))
+	require.NoError(t, err)
+	assert.Empty(t, c.Items)
+
+	c.Replace(testItems())
+	require.NoError(t, c.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, plaintext := range []string{"prod-db", "root access via bastion", "private diary entry"} {
+		assert.NotContains(t, string(data), plaintext)
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	reopened, err := Open(path, testKey(1))
+	require.NoError(t, err)
+	items := testItems()
+	assert.Equal(t, []Item{items[1], items[0]}, reopened.List())
+	assert.False(t, reopened.SyncedAt.IsZero())
+}
+
+func TestOpen_Unreadable(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, path string)
+	}{
+		{
+			name: "Other key",
+			prepare: func(t *testing.T, path string) {
+				c, err := Open(path, testKey(2))
+				require.NoError(t, err)
+				c.Replace(testItems())
+				require.NoError(t, c.Save())
+			},
+		},
+		{
+			name: "Plaintext file",
+			prepare: func(t *testing.T, path string) {
+				require.NoError(t, os.WriteFile(path, []byte(`{"version":2,"items":{}}`), 0o600))
+			},
+		},
+		{
+			name: "Truncated file",
+			prepare: func(t *testing.T, path string) {
+				require.NoError(t, os.WriteFile(path, []byte(fileMagic), 0o600))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), fileName)
+			tt.prepare(t, path)
+
+			c, err := Open(path, testKey(1))
+			require.NoError(t, err)
+			assert.Empty(t, c.Items)
+			assert.True(t, c.SyncedAt.IsZero())
+			assert.NoFileExists(t, path)
+		})
+	}
+}
+
+func TestOpen_RemovesLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, legacyFileName)
+	require.NoError(t, os.WriteFile(legacy, []byte(`{"items":{"a":{"content":"secret"}}}`), 0o600))
+
+	_, err := Open(filepath.Join(dir, fileName), testKey(1))
+	require.NoError(t, err)
+	assert.NoFileExists(t, legacy)
+}
+
+func TestOpen_InvalidKey(t *testing.T) {
+	_, err := Open(filepath.Join(t.TempDir(), fileName), []byte("short"))
+	require.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ClearAfter ждет timeout и очищает буфер обмена, если в нем все еще
+// находится text. Если за это время буфер изменился, он не очищается.
+// Если содержимое буфера прочитать нельзя, он очищается без проверки.
+// При отмене ctx буфер очищается сразу. Возвращает true, если буфер
+// был очищен.
+func ClearAfter(ctx context.Context, c Clipboard, text string, timeout time.Duration) (bool, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	current, err := c.Paste()
+	switch {
+	case errors.Is(err, ErrNoPaste):
+	case err != nil:
+		return false, err
+	case current != text:
+		return false, nil
+	}
+
+	if err := c.Copy(""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,118 @@
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClipboard буфер обмена в памяти
+type fakeClipboard struct {
+	content  string
+	pasteErr error
+	copyErr  error
+	copies   []string
+}
+
+func (c *fakeClipboard) Copy(text string) error {
+	if c.copyErr != nil {
+		return c.copyErr
+	}
+	c.copies = append(c.copies, text)
+	c.content = text
+	return nil
+}
+
+func (c *fakeClipboard) Paste() (string, error) {
+	if c.pasteErr != nil {
+		return "", c.pasteErr
+	}
+	return c.content, nil
+}
+
+func TestClearAfter(t *testing.T) {
+	errBroken := errors.New("broken clipboard")
+
+	tests := []struct {
+		name      string
+		clipboard *fakeClipboard
+		cleared   bool
+		err       error
+		content   string
+		copies    []string
+	}{
+		{
+			name:      "Unchanged",
+			clipboard: &fakeClipboard{content: "s3cret"},
+			cleared:   true,
+			copies:    []string{""},
+		},
+		{
+			name:      "Changed",
+			clipboard: &fakeClipboard{content: "copied later"},
+			content:   "copied later",
+		},
+		{
+			name:      "Paste not supported",
+			clipboard: &fakeClipboard{content: "copied later", pasteErr: ErrNoPaste},
+			cleared:   true,
+			copies:    []string{""},
+		},
+		{
+			name:      "Paste error",
+			clipboard: &fakeClipboard{content: "s3cret", pasteErr: errBroken},
+			err:       errBroken,
+			content:   "s3cret",
+		},
+		{
+			name:      "Copy error",
+			clipboard: &fakeClipboard{content: "s3cret", copyErr: errBroken},
+			err:       errBroken,
+			content:   "s3cret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleared, err := ClearAfter(context.Background(), tt.clipboard, "s3cret", time.Millisecond)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.cleared, cleared)
+			assert.Equal(t, tt.content, tt.clipboard.content)
+			assert.Equal(t, tt.copies, tt.clipboard.copies)
+		})
+	}
+}
+
+func TestClearAfter_Canceled(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		cleared bool
+	}{
+		{name: "Unchanged", content: "s3cret", cleared: true},
+		{name: "Changed", content: "copied later", cleared: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// При отмене контекста таймаут не дожидается
+			clipboard := &fakeClipboard{content: tt.content}
+			start := time.Now()
+			cleared, err := ClearAfter(ctx, clipboard, "s3cret", time.Hour)
+			require.NoError(t, err)
+			assert.Less(t, time.Since(start), time.Minute)
+			assert.Equal(t, tt.cleared, cleared)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+var (
+	ErrUnavailable = errors.New("no clipboard utility found, install wl-clipboard, xclip or xsel")
+	// ErrNoPaste возвращается, если содержимое буфера обмена нельзя прочитать
+	ErrNoPaste = errors.New("clipboard can not be read")
+)
+
+// Backend способ доступа к буферу обмена
+type Backend string
+
+const (
+	// Auto выбирает OSC52 в SSH-сессии без графического окружения,
+	// иначе системную утилиту
+	Auto Backend = "auto"
+	// Native системная утилита: pbcopy, clip.exe, wl-copy, xclip или xsel
+	Native Backend = "system"
+	// Terminal управляющая последовательность OSC52 терминала
+	Terminal Backend = "osc52"
+)
+
+// Clipboard буфер обмена
+type Clipboard interface {
+	// Copy помещает текст в буфер обмена, пустая строка очищает буфер
+	Copy(text string) error
+	// Paste возвращает содержимое буфера обмена или ErrNoPaste
+	Paste() (string, error)
+}
+
+// New возвращает буфер обмена с указанным способом доступа
+func New(backend Backend) (Clipboard, error) {
+	switch backend {
+	case "", Auto:
+		if remoteSession() {
+			return OSC52(), nil
+		}
+		if c, err := System(); err == nil {
+			return c, nil
+		}
+		return OSC52(), nil
+	case Native:
+		return System()
+	case Terminal:
+		return OSC52(), nil
+	}
+	return nil, fmt.Errorf("unknown clipboard backend %q, expected one of: %s, %s, %s",
+		backend, Auto, Native, Terminal)
+}
+
+// remoteSession проверяет, что клиент запущен в SSH-сессии
+// без доступа к графическому окружению
+func remoteSession() bool {
+	if os.Getenv("SSH_TTY") == "" && os.Getenv("SSH_CONNECTION") == "" {
+		return false
+	}
+	return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+// command буфер обмена, работающий через внешнюю утилиту
+type command struct {
+	name  string
+	args  []string
+	paste []string
+}
+
+// System возвращает системный буфер обмена. Утилита выбирается по
+// платформе: pbcopy на macOS, clip.exe на Windows и в WSL, wl-copy,
+// xclip или xsel в остальных системах.
+func System() (Clipboard, error) {
+	for _, candidate := range candidates() {
+		if _, err := exec.LookPath(candidate.name); err == nil {
+			return candidate, nil
+		}
+	}
+	return nil, ErrUnavailable
+}
+
+func candidates() []command {
+	windows := command{
+		name:  "clip.exe",
+		paste: []string{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard -Raw"},
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return []command{{name: "pbcopy", paste: []string{"pbpaste"}}}
+	case "windows":
+		return []command{windows}
+	}
+
+	var list []command
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		list = append(list, command{name: "wl-copy", paste: []string{"wl-paste", "--no-newline"}})
+	}
+	list = append(list,
+		command{
+			name:  "xclip",
+			args:  []string{"-selection", "clipboard"},
+			paste: []string{"xclip", "-selection", "clipboard", "-o"},
+		},
+		command{
+			name:  "xsel",
+			args:  []string{"--clipboard", "--input"},
+			paste: []string{"xsel", "--clipboard", "--output"},
+		},
+		windows,
+	)
+	return list
+}
+
+// Copy помещает текст в буфер обмена
+func (c command) Copy(text string) error {
+	cmd := exec.Command(c.name, c.args...)
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// Paste возвращает содержимое буфера обмена
+func (c command) Paste() (string, error) {
+	if len(c.paste) == 0 {
+		return "", ErrNoPaste
+	}
+	if _, err := exec.LookPath(c.paste[0]); err != nil {
+		return "", ErrNoPaste
+	}
+
+	out, err := exec.Command(c.paste[0], c.paste[1:]...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\r\n"), nil
+}
@@ -0,0 +1,56 @@
+package clipboard
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// terminal буфер обмена терминала, доступный через OSC52. Работает и
+// в SSH-сессии: последовательность передается локальному терминалу.
+type terminal struct {
+	out  io.Writer
+	mode osc52.Mode
+}
+
+// OSC52 возвращает буфер обмена терминала. Последовательность пишется
+// в управляющий терминал, а если его нет, в stderr.
+func OSC52() Clipboard {
+	var out io.Writer = os.Stderr
+	if tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0); err == nil {
+		out = tty
+	}
+	return NewOSC52(out)
+}
+
+// NewOSC52 возвращает буфер обмена терминала, который пишет
+// последовательности в out. Внутри tmux и screen последовательность
+// оборачивается, чтобы дойти до внешнего терминала.
+func NewOSC52(out io.Writer) Clipboard {
+	mode := osc52.DefaultMode
+	switch {
+	case os.Getenv("TMUX") != "":
+		mode = osc52.TmuxMode
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		mode = osc52.ScreenMode
+	}
+	return terminal{out: out, mode: mode}
+}
+
+// Copy помещает текст в буфер обмена терминала
+func (t terminal) Copy(text string) error {
+	seq := osc52.New(text)
+	if text == "" {
+		seq = osc52.Clear()
+	}
+	_, err := seq.Mode(t.mode).WriteTo(t.out)
+	return err
+}
+
+// Paste не поддерживается: большинство терминалов не отвечают на запрос
+// содержимого буфера обмена
+func (t terminal) Paste() (string, error) {
+	return "", ErrNoPaste
+}
@@ -0,0 +1,59 @@
+package clipboard
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOSC52(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmux     string
+		term     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Copy",
+			term:     "xterm-256color",
+			text:     "s3cret",
+			expected: "\x1b]52;c;czNjcmV0\x07",
+		},
+		{
+			name:     "Clear",
+			term:     "xterm-256color",
+			expected: "\x1b]52;c;!\x07",
+		},
+		{
+			name:     "Tmux",
+			tmux:     "/tmp/tmux-1000/default,1,0",
+			term:     "screen-256color",
+			text:     "s3cret",
+			expected: "\x1bPtmux;\x1b\x1b]52;c;czNjcmV0\x07\x1b\\",
+		},
+		{
+			name:     "Screen",
+			term:     "screen",
+			text:     "s3cret",
+			expected: "\x1bP\x1b]52;c;czNjcmV0\x07\x1b\\",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TMUX", tt.tmux)
+			t.Setenv("TERM", tt.term)
+
+			var out bytes.Buffer
+			clipboard := NewOSC52(&out)
+			require.NoError(t, clipboard.Copy(tt.text))
+			assert.Equal(t, tt.expected, out.String())
+
+			_, err := clipboard.Paste()
+			require.ErrorIs(t, err, ErrNoPaste)
+		})
+	}
+}
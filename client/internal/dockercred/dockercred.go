@@ -0,0 +1,68 @@
+package dockercred
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Binary имя исполняемого файла, под которым docker ищет помощника
+// для "credsStore": "gophkeeper"
+const Binary = "docker-credential-gophkeeper"
+
+// DefaultPrefix префикс имен секретов с учетными данными реестров
+const DefaultPrefix = "docker/"
+
+// Tag метка секретов с учетными данными реестров
+const Tag = "docker"
+
+// ErrNotFound ошибка, которую docker распознает как отсутствие учетных данных
+var ErrNotFound = errors.New("credentials not found in native keychain")
+
+// Credentials учетные данные реестра в протоколе docker credential helper
+type Credentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// ReadServerURL читает адрес реестра, переданный командам get и erase
+func ReadServerURL(r io.Reader) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	serverURL := strings.TrimSpace(string(b))
+	if serverURL == "" {
+		return "", errors.New("no credentials server URL")
+	}
+	return serverURL, nil
+}
+
+// ReadCredentials читает учетные данные, переданные команде store
+func ReadCredentials(r io.Reader) (Credentials, error) {
+	var c Credentials
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return Credentials{}, fmt.Errorf("invalid credentials: %w", err)
+	}
+	if strings.TrimSpace(c.ServerURL) == "" {
+		return Credentials{}, errors.New("no credentials server URL")
+	}
+	if c.Username == "" {
+		return Credentials{}, errors.New("no credentials username")
+	}
+	return c, nil
+}
+
+// Name возвращает имя секрета для реестра. Схема и завершающий "/"
+// отбрасываются, чтобы https://registry.example.com/ и registry.example.com
+// указывали на один секрет.
+func Name(prefix, serverURL string) string {
+	host := strings.TrimSpace(serverURL)
+	if _, rest, ok := strings.Cut(host, "://"); ok {
+		host = rest
+	}
+	return prefix + strings.TrimRight(host, "/")
+}
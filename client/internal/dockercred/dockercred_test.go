@@ -0,0 +1,110 @@
+package dockercred
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string
+		serverURL string
+		expected  string
+	}{
+		{
+			name:      "Bare host",
+			prefix:    DefaultPrefix,
+			serverURL: "registry.example.com",
+			expected:  "docker/registry.example.com",
+		},
+		{
+			name:      "Scheme and trailing slash",
+			prefix:    DefaultPrefix,
+			serverURL: "https://registry.example.com/",
+			expected:  "docker/registry.example.com",
+		},
+		{
+			name:      "Docker Hub",
+			prefix:    DefaultPrefix,
+			serverURL: "https://index.docker.io/v1/",
+			expected:  "docker/index.docker.io/v1",
+		},
+		{
+			name:      "Host with port and spaces",
+			prefix:    DefaultPrefix,
+			serverURL: "  http://localhost:5000  ",
+			expected:  "docker/localhost:5000",
+		},
+		{
+			name:      "Custom prefix",
+			prefix:    "registries/",
+			serverURL: "ghcr.io",
+			expected:  "registries/ghcr.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Name(tt.prefix, tt.serverURL))
+		})
+	}
+}
+
+func TestReadServerURL(t *testing.T) {
+	serverURL, err := ReadServerURL(strings.NewReader("https://ghcr.io\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://ghcr.io", serverURL)
+
+	_, err = ReadServerURL(strings.NewReader(" \n"))
+	require.Error(t, err)
+}
+
+func TestReadCredentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    Credentials
+		expectedErr string
+	}{
+		{
+			name:  "Valid",
+			input: `{"ServerURL":"ghcr.io","Username":"alice","Secret":"token"}`,
+			expected: Credentials{
+				ServerURL: "ghcr.io",
+				Username:  "alice",
+				Secret:    "token",
+			},
+		},
+		{
+			name:        "Invalid JSON",
+			input:       `{"ServerURL":`,
+			expectedErr: "invalid credentials",
+		},
+		{
+			name:        "Empty server URL",
+			input:       `{"ServerURL":" ","Username":"alice","Secret":"token"}`,
+			expectedErr: "no credentials server URL",
+		},
+		{
+			name:        "Empty username",
+			input:       `{"ServerURL":"ghcr.io","Secret":"token"}`,
+			expectedErr: "no credentials username",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ReadCredentials(strings.NewReader(tt.input))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, c)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+)
+
+const (
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars  = "0123456789"
+	symbolChars = "!@#$%^&*()-_=+[]{};:,.<>/?~"
+
+	// ambiguousChars символы, которые легко спутать при чтении
+	ambiguousChars = "Il1|O0o`'\""
+)
+
+//go:embed wordlist.txt
+var wordlistData string
+
+// wordlist список слов для фраз-паролей
+var wordlist = strings.Fields(wordlistData)
+
+var (
+	ErrNoClasses = errors.New("at least one character class is required")
+	ErrLength    = errors.New("password is too short for the selected character classes")
+	ErrWords     = errors.New("passphrase needs at least one word")
+)
+
+// Options параметры генерации пароля
+type Options struct {
+	Length           int
+	Lower            bool
+	Upper            bool
+	Digits           bool
+	Symbols          bool
+	ExcludeAmbiguous bool
+}
+
+// PassphraseOptions параметры генерации фразы-пароля
+type PassphraseOptions struct {
+	Words      int
+	Separator  string
+	Capitalize bool
+	Digit      bool
+}
+
+// Password генерирует пароль и возвращает его энтропию в битах.
+// В пароле есть хотя бы один символ каждого выбранного класса.
+func Password(opts Options) (string, float64, error) {
+	classes := make([]string, 0, 4)
+	for _, class := range []struct {
+		enabled bool
+		chars   string
+	}{
+		{opts.Lower, lowerChars},
+		{opts.Upper, upperChars},
+		{opts.Digits, digitChars},
+		{opts.Symbols, symbolChars},
+	} {
+		if !class.enabled {
+			continue
+		}
+		chars := class.chars
+		if opts.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(ambiguousChars, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+
+	if len(classes) == 0 {
+		return "", 0, ErrNoClasses
+	}
+	if opts.Length < len(classes) {
+		return "", 0, ErrLength
+	}
+
+	alphabet := strings.Join(classes, "")
+	password := make([]byte, opts.Length)
+	for {
+		for i := range password {
+			n, err := randomInt(len(alphabet))
+			if err != nil {
+				return "", 0, err
+			}
+			password[i] = alphabet[n]
+		}
+
+		// Пароли без одного из классов отбрасываются целиком, чтобы
+		// не нарушать равномерность распределения символов.
+		if containsAll(string(password), classes) {
+			break
+		}
+	}
+
+	return string(password), float64(opts.Length) * math.Log2(float64(len(alphabet))), nil
+}
+
+// Passphrase генерирует фразу-пароль из слов встроенного словаря
+// и возвращает ее энтропию в битах.
+func Passphrase(opts PassphraseOptions) (string, float64, error) {
+	if opts.Words < 1 {
+		return "", 0, ErrWords
+	}
+
+	words := make([]string, opts.Words)
+	for i := range words {
+		n, err := randomInt(len(wordlist))
+		if err != nil {
+			return "", 0, err
+		}
+		words[i] = wordlist[n]
+		if opts.Capitalize {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	entropy := float64(opts.Words) * math.Log2(float64(len(wordlist)))
+
+	if opts.Digit {
+		n, err := randomInt(len(words))
+		if err != nil {
+			return "", 0, err
+		}
+		d, err := randomInt(len(digitChars))
+		if err != nil {
+			return "", 0, err
+		}
+		words[n] += digitChars[d : d+1]
+		entropy += math.Log2(float64(len(words) * len(digitChars)))
+	}
+
+	return strings.Join(words, opts.Separator), entropy, nil
+}
+
+// Strength возвращает словесную оценку энтропии
+func Strength(entropy float64) string {
+	switch {
+	case entropy < 40:
+		return "weak"
+	case entropy < 60:
+		return "fair"
+	case entropy < 80:
+		return "strong"
+	default:
+		return "very strong"
+	}
+}
+
+// WordCount возвращает число слов во встроенном словаре
+func WordCount() int {
+	return len(wordlist)
+}
+
+// Words возвращает копию встроенного словаря
+func Words() []string {
+	return append([]string(nil), wordlist...)
+}
+
+func containsAll(password string, classes []string) bool {
+	for _, class := range classes {
+		if !strings.ContainsAny(password, class) {
+			return false
+		}
+	}
+	return true
+}
+
+func randomInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, err
+	}
+	return int(n.Int64()), nil
+}
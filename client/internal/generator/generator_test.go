@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		classes     []string
+		alphabet    int
+		expectedErr error
+	}{
+		{
+			name:     "All classes",
+			opts:     Options{Length: 20, Lower: true, Upper: true, Digits: true, Symbols: true},
+			classes:  []string{lowerChars, upperChars, digitChars, symbolChars},
+			alphabet: len(lowerChars) + len(upperChars) + len(digitChars) + len(symbolChars),
+		},
+		{
+			name:     "Digits only",
+			opts:     Options{Length: 6, Digits: true},
+			classes:  []string{digitChars},
+			alphabet: len(digitChars),
+		},
+		{
+			name:     "Without ambiguous characters",
+			opts:     Options{Length: 64, Lower: true, Upper: true, Digits: true, ExcludeAmbiguous: true},
+			classes:  []string{lowerChars, upperChars, digitChars},
+			alphabet: len(lowerChars) + len(upperChars) + len(digitChars) - 6,
+		},
+		{
+			name:        "No classes",
+			opts:        Options{Length: 16},
+			expectedErr: ErrNoClasses,
+		},
+		{
+			name:        "Shorter than classes",
+			opts:        Options{Length: 3, Lower: true, Upper: true, Digits: true, Symbols: true},
+			expectedErr: ErrLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			password, entropy, err := Password(tt.opts)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Len(t, password, tt.opts.Length)
+			assert.InDelta(t, float64(tt.opts.Length)*math.Log2(float64(tt.alphabet)), entropy, 1e-9)
+			for _, class := range tt.classes {
+				assert.True(t, strings.ContainsAny(password, class), "missing class %q", class)
+			}
+			if tt.opts.ExcludeAmbiguous {
+				assert.False(t, strings.ContainsAny(password, ambiguousChars))
+			}
+		})
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	words := make(map[string]bool, WordCount())
+	for _, word := range Words() {
+		words[word] = true
+	}
+
+	phrase, entropy, err := Passphrase(PassphraseOptions{Words: 5, Separator: "-"})
+	require.NoError(t, err)
+
+	parts := strings.Split(phrase, "-")
+	require.Len(t, parts, 5)
+	for _, part := range parts {
+		assert.True(t, words[part], "word %q is not in the wordlist", part)
+	}
+	assert.InDelta(t, 5*math.Log2(float64(WordCount())), entropy, 1e-9)
+
+	phrase, _, err = Passphrase(PassphraseOptions{Words: 4, Separator: " ", Capitalize: true, Digit: true})
+	require.NoError(t, err)
+	parts = strings.Split(phrase, " ")
+	require.Len(t, parts, 4)
+	digits := 0
+	for _, part := range parts {
+		assert.Equal(t, strings.ToUpper(part[:1]), part[:1])
+		if strings.ContainsAny(part, digitChars) {
+			digits++
+		}
+	}
+	assert.Equal(t, 1, digits)
+
+	_, _, err = Passphrase(PassphraseOptions{})
+	require.ErrorIs(t, err, ErrWords)
+}
+
+func TestStrength(t *testing.T) {
+	tests := []struct {
+		entropy  float64
+		expected string
+	}{
+		{entropy: 0, expected: "weak"},
+		{entropy: 39.9, expected: "weak"},
+		{entropy: 40, expected: "fair"},
+		{entropy: 60, expected: "strong"},
+		{entropy: 80, expected: "very strong"},
+		{entropy: 128, expected: "very strong"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, Strength(tt.entropy), "entropy %v", tt.entropy)
+	}
+}
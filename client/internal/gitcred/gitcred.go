@@ -0,0 +1,151 @@
+package gitcred
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// DefaultNameTemplate шаблон имени секрета по умолчанию
+const DefaultNameTemplate = `git/{{ .Host }}{{ with .Path }}/{{ . }}{{ end }}`
+
+// Credential описание учетных данных в протоколе git credential helper
+type Credential struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// Read читает описание учетных данных: строки key=value до пустой строки
+// или конца ввода. Атрибут url раскладывается на protocol, host, path
+// и username, остальные атрибуты (wwwauth[], capability[] и т.п.)
+// пропускаются.
+func Read(r io.Reader) (Credential, error) {
+	var c Credential
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return Credential{}, fmt.Errorf("invalid credential line %q", line)
+		}
+		switch key {
+		case "protocol":
+			c.Protocol = value
+		case "host":
+			c.Host = value
+		case "path":
+			c.Path = value
+		case "username":
+			c.Username = value
+		case "password":
+			c.Password = value
+		case "url":
+			if err := c.setURL(value); err != nil {
+				return Credential{}, err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Credential{}, err
+	}
+	return c, nil
+}
+
+func (c *Credential) setURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid credential url: %w", err)
+	}
+	c.Protocol = u.Scheme
+	c.Host = u.Host
+	c.Path = strings.TrimPrefix(u.Path, "/")
+	if u.User != nil {
+		c.Username = u.User.Username()
+		if password, ok := u.User.Password(); ok {
+			c.Password = password
+		}
+	}
+	return nil
+}
+
+// Write отвечает git логином и паролем
+func Write(w io.Writer, username, password string) error {
+	_, err := fmt.Fprintf(w, "username=%s\npassword=%s\n", username, password)
+	return err
+}
+
+// URL адрес, для которого запрошены учетные данные
+func (c Credential) URL() string {
+	u := url.URL{Scheme: c.Protocol, Host: c.Host}
+	if c.Path != "" {
+		u.Path = "/" + c.Path
+	}
+	return u.String()
+}
+
+// Namer строит имена секретов по шаблону. В шаблоне доступны
+// поля .Protocol, .Host, .Path и .Username.
+type Namer struct {
+	tmpl *template.Template
+}
+
+// NewNamer разбирает шаблон имени секрета
+func NewNamer(text string) (*Namer, error) {
+	if text == "" {
+		text = DefaultNameTemplate
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret name template: %w", err)
+	}
+	return &Namer{tmpl: tmpl}, nil
+}
+
+// Names возвращает имена, под которыми могут храниться учетные данные,
+// в порядке предпочтения. Git передает путь, только если включен
+// credential.useHttpPath, поэтому вторым вариантом идет имя без пути.
+func (n *Namer) Names(c Credential) ([]string, error) {
+	name, err := n.Name(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.Path == "" {
+		return []string{name}, nil
+	}
+
+	c.Path = ""
+	hostName, err := n.Name(c)
+	if err != nil {
+		return nil, err
+	}
+	if hostName == name {
+		return []string{name}, nil
+	}
+	return []string{name, hostName}, nil
+}
+
+// Name возвращает имя секрета для учетных данных
+func (n *Namer) Name(c Credential) (string, error) {
+	if c.Host == "" {
+		return "", fmt.Errorf("credential has no host")
+	}
+
+	var name strings.Builder
+	if err := n.tmpl.Execute(&name, c); err != nil {
+		return "", fmt.Errorf("secret name template: %w", err)
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("secret name template produced an empty name")
+	}
+	return name.String(), nil
+}
@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/otp"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+const (
+	bitwardenLogin    = 1
+	bitwardenNote     = 2
+	bitwardenCard     = 3
+	bitwardenIdentity = 4
+)
+
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Folders   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"folders"`
+	Items []bitwardenItem `json:"items"`
+}
+
+type bitwardenItem struct {
+	Type     int    `json:"type"`
+	Name     string `json:"name"`
+	Notes    string `json:"notes"`
+	FolderID string `json:"folderId"`
+	Login    struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		TOTP     string `json:"totp"`
+		URIs     []struct {
+			URI string `json:"uri"`
+		} `json:"uris"`
+	} `json:"login"`
+	Card struct {
+		CardholderName string `json:"cardholderName"`
+		Number         string `json:"number"`
+		ExpMonth       string `json:"expMonth"`
+		ExpYear        string `json:"expYear"`
+		Code           string `json:"code"`
+	} `json:"card"`
+	Identity map[string]any `json:"identity"`
+	Fields   []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"fields"`
+}
+
+// parseBitwardenJSON разбирает незашифрованный экспорт Bitwarden в формате JSON
+func parseBitwardenJSON(data []byte) ([]Record, error) {
+	var export bitwardenExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("bitwarden json: %w", err)
+	}
+	if export.Encrypted {
+		return nil, errors.New("bitwarden json: encrypted exports are not supported, export unencrypted json")
+	}
+
+	folders := make(map[string]string, len(export.Folders))
+	for _, folder := range export.Folders {
+		folders[folder.ID] = folder.Name
+	}
+
+	records := make([]Record, 0, len(export.Items))
+	for _, item := range export.Items {
+		extra := make([][2]string, 0, len(item.Fields))
+		for _, field := range item.Fields {
+			extra = append(extra, [2]string{field.Name, field.Value})
+		}
+		notes := extraNotes(item.Notes, extra)
+		folder := folders[item.FolderID]
+
+		var secret vaulttypes.Vault
+		var attached []Record
+		switch item.Type {
+		case bitwardenLogin:
+			url := ""
+			if len(item.Login.URIs) > 0 {
+				url = item.Login.URIs[0].URI
+			}
+			secret = newLogin(item.Login.Username, item.Login.Password, url, notes)
+
+			if item.Login.TOTP != "" {
+				totp, err := bitwardenTOTP(item.Login.TOTP)
+				if err != nil {
+					return nil, fmt.Errorf("bitwarden json: item %s: totp: %w", item.Name, err)
+				}
+				attached = append(attached, Record{Name: item.Name + "/otp", Folder: folder, Secret: totp})
+			}
+		case bitwardenCard:
+			expiry := ""
+			if item.Card.ExpMonth != "" || item.Card.ExpYear != "" {
+				expiry = fmt.Sprintf("%s/%s", item.Card.ExpMonth, item.Card.ExpYear)
+			}
+			secret = vaulttypes.Card{
+				Number:       item.Card.Number,
+				ExpiryDate:   expiry,
+				SecurityCode: item.Card.Code,
+				Holder:       item.Card.CardholderName,
+				Notes:        notes,
+			}
+		case bitwardenIdentity:
+			fields := make([][2]string, 0, len(item.Identity))
+			for key, value := range item.Identity {
+				if value != nil {
+					fields = append(fields, [2]string{key, fmt.Sprint(value)})
+				}
+			}
+			secret = vaulttypes.Text{Data: extraNotes(notes, sortedFields(fields))}
+		case bitwardenNote:
+			secret = vaulttypes.Text{Data: notes}
+		default:
+			return nil, fmt.Errorf("bitwarden json: item %s: unknown type %d", item.Name, item.Type)
+		}
+
+		records = append(records, Record{Name: item.Name, Folder: folder, Secret: secret})
+		records = append(records, attached...)
+	}
+	return records, nil
+}
+
+// bitwardenTOTP разбирает значение TOTP: ссылку otpauth:// или секрет в base32
+func bitwardenTOTP(value string) (vaulttypes.OTP, error) {
+	if secret, err := otp.ParseURI(value); err == nil {
+		return secret, nil
+	}
+
+	secret := otp.Normalize(vaulttypes.OTP{Kind: otp.KindTOTP, Secret: value})
+	return secret, otp.Validate(secret)
+}
@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// parseChromeCSV разбирает экспорт паролей Chrome и совместимых браузеров.
+// Колонки определяются по заголовку: name, url, username, password, note.
+func parseChromeCSV(data []byte) ([]Record, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("chrome csv: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"url", "username", "password"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("chrome csv: column %q not found", required)
+		}
+	}
+
+	column := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+
+	records := make([]Record, 0)
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("chrome csv: %w", err)
+		}
+
+		name := column(row, "name")
+		if name == "" {
+			name = column(row, "url")
+		}
+
+		records = append(records, Record{
+			Name: name,
+			Secret: newLogin(column(row, "username"), column(row, "password"),
+				column(row, "url"), column(row, "note")),
+		})
+	}
+	return records, nil
+}
+
+// sortedFields сортирует дополнительные поля по имени для стабильного вывода
+func sortedFields(fields [][2]string) [][2]string {
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i][0] < fields[j][0]
+	})
+	return fields
+}
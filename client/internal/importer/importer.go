@@ -0,0 +1,86 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// Record секрет, прочитанный из файла экспорта другого менеджера паролей
+type Record struct {
+	Name   string
+	Folder string
+	Tags   []string
+	Secret vaulttypes.Vault
+}
+
+// Parser разбирает содержимое файла экспорта
+type Parser func(data []byte) ([]Record, error)
+
+var parsers = map[string]Parser{
+	"keepass-xml":    parseKeePassXML,
+	"bitwarden-json": parseBitwardenJSON,
+	"1password-1pux": parse1PUX,
+	"chrome-csv":     parseChromeCSV,
+}
+
+// Formats возвращает поддерживаемые форматы импорта
+func Formats() []string {
+	formats := make([]string, 0, len(parsers))
+	for format := range parsers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Parse разбирает файл экспорта указанного формата
+func Parse(format string, data []byte) ([]Record, error) {
+	parser, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown import format %q, supported: %s",
+			format, strings.Join(Formats(), ", "))
+	}
+	return parser(data)
+}
+
+// newLogin создает секрет для учетной записи. Записи без логина и пароля
+// сохраняются как текстовые заметки.
+func newLogin(login, password, url, notes string) vaulttypes.Vault {
+	if login == "" && password == "" {
+		return vaulttypes.Text{Data: joinNotes(url, notes)}
+	}
+	return vaulttypes.Credentials{
+		Login:    login,
+		Password: password,
+		URL:      url,
+		Notes:    notes,
+	}
+}
+
+// extraNotes добавляет к заметкам дополнительные поля записи
+func extraNotes(notes string, fields [][2]string) string {
+	lines := make([]string, 0, len(fields)+1)
+	if notes != "" {
+		lines = append(lines, notes)
+	}
+	for _, field := range fields {
+		if field[1] == "" {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", field[0], field[1]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func joinNotes(parts ...string) string {
+	lines := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			lines = append(lines, part)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,377 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+func TestParse_UnknownFormat(t *testing.T) {
+	_, err := Parse("lastpass-csv", nil)
+	require.ErrorContains(t, err, `unknown import format "lastpass-csv"`)
+	assert.Contains(t, err.Error(), "chrome-csv")
+}
+
+func TestParse_ChromeCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expected    []Record
+		expectedErr string
+	}{
+		{
+			name: "Logins",
+			data: "\ufeffname,url,username,password,note\n" +
+				"Example,https://example.com,alice,s3cret,\n" +
+				",https://nameless.example.com,bob,pa55,shared account\n",
+			expected: []Record{
+				{
+					Name: "Example",
+					Secret: vaulttypes.Credentials{
+						Login:    "alice",
+						Password: "s3cret",
+						URL:      "https://example.com",
+					},
+				},
+				{
+					Name: "https://nameless.example.com",
+					Secret: vaulttypes.Credentials{
+						Login:    "bob",
+						Password: "pa55",
+						URL:      "https://nameless.example.com",
+						Notes:    "shared account",
+					},
+				},
+			},
+		},
+		{
+			name: "Entry without login and password",
+			data: "url,username,password\nhttps://example.com,,\n",
+			expected: []Record{
+				{
+					Name:   "https://example.com",
+					Secret: vaulttypes.Text{Data: "https://example.com"},
+				},
+			},
+		},
+		{
+			name:        "Missing column",
+			data:        "name,url,username\nExample,https://example.com,alice\n",
+			expectedErr: `column "password" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := Parse("chrome-csv", []byte(tt.data))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, records)
+		})
+	}
+}
+
+func TestParse_BitwardenJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expected    []Record
+		expectedErr string
+	}{
+		{
+			name: "Login with folder, fields and totp",
+			data: `{
+				"encrypted": false,
+				"folders": [{"id": "f1", "name": "Work"}],
+				"items": [{
+					"type": 1,
+					"name": "GitHub",
+					"notes": "main account",
+					"folderId": "f1",
+					"login": {
+						"username": "alice",
+						"password": "s3cret",
+						"totp": "JBSWY3DPEHPK3PXP",
+						"uris": [{"uri": "https://github.com"}]
+					},
+					"fields": [{"name": "recovery", "value": "abc"}]
+				}]
+			}`,
+			expected: []Record{
+				{
+					Name:   "GitHub",
+					Folder: "Work",
+					Secret: vaulttypes.Credentials{
+						Login:    "alice",
+						Password: "s3cret",
+						URL:      "https://github.com",
+						Notes:    "main account\nrecovery: abc",
+					},
+				},
+				{
+					Name:   "GitHub/otp",
+					Folder: "Work",
+					Secret: vaulttypes.OTP{
+						Kind:      "totp",
+						Secret:    "JBSWY3DPEHPK3PXP",
+						Algorithm: "SHA1",
+						Digits:    6,
+						Period:    30,
+					},
+				},
+			},
+		},
+		{
+			name: "Card and note",
+			data: `{"items": [
+				{"type": 3, "name": "Visa", "card": {
+					"cardholderName": "Alice", "number": "4111111111111111",
+					"expMonth": "12", "expYear": "2030", "code": "123"}},
+				{"type": 2, "name": "Memo", "notes": "remember"}
+			]}`,
+			expected: []Record{
+				{
+					Name: "Visa",
+					Secret: vaulttypes.Card{
+						Number:       "4111111111111111",
+						ExpiryDate:   "12/2030",
+						SecurityCode: "123",
+						Holder:       "Alice",
+					},
+				},
+				{
+					Name:   "Memo",
+					Secret: vaulttypes.Text{Data: "remember"},
+				},
+			},
+		},
+		{
+			name:        "Encrypted export",
+			data:        `{"encrypted": true, "items": []}`,
+			expectedErr: "encrypted exports are not supported",
+		},
+		{
+			name:        "Unknown item type",
+			data:        `{"items": [{"type": 9, "name": "Odd"}]}`,
+			expectedErr: "unknown type 9",
+		},
+		{
+			name:        "Invalid totp",
+			data:        `{"items": [{"type": 1, "name": "Bad", "login": {"username": "a", "totp": "not base32!"}}]}`,
+			expectedErr: "item Bad: totp",
+		},
+		{
+			name:        "Invalid JSON",
+			data:        `{"items": [`,
+			expectedErr: "bitwarden json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := Parse("bitwarden-json", []byte(tt.data))
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, records)
+		})
+	}
+}
+
+func TestParse_KeePassXML(t *testing.T) {
+	const data = `<?xml version="1.0" encoding="utf-8"?>
+<KeePassFile>
+	<Meta>
+		<RecycleBinUUID>bin</RecycleBinUUID>
+		<Binaries>
+			<Binary ID="0" Compressed="False">aGVsbG8=</Binary>
+		</Binaries>
+	</Meta>
+	<Root>
+		<Group>
+			<UUID>root</UUID>
+			<Name>Database</Name>
+			<Entry>
+				<Tags>db; prod</Tags>
+				<String><Key>Title</Key><Value>prod-db</Value></String>
+				<String><Key>UserName</Key><Value>admin</Value></String>
+				<String><Key>Password</Key><Value>s3cret</Value></String>
+				<String><Key>Port</Key><Value>5432</Value></String>
+				<Binary><Key>cert.pem</Key><Value Ref="0"/></Binary>
+			</Entry>
+			<Group>
+				<UUID>work</UUID>
+				<Name>Work</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>Wiki</Value></String>
+					<String><Key>UserName</Key><Value>alice</Value></String>
+					<String><Key>Password</Key><Value>pa55</Value></String>
+					<String><Key>URL</Key><Value>https://wiki.example.com</Value></String>
+				</Entry>
+			</Group>
+			<Group>
+				<UUID>bin</UUID>
+				<Name>Recycle Bin</Name>
+				<Entry>
+					<String><Key>Title</Key><Value>deleted</Value></String>
+					<String><Key>Password</Key><Value>old</Value></String>
+				</Entry>
+			</Group>
+		</Group>
+	</Root>
+</KeePassFile>`
+
+	records, err := Parse("keepass-xml", []byte(data))
+	require.NoError(t, err)
+	assert.Equal(t, []Record{
+		{
+			Name: "prod-db",
+			Tags: []string{"db", "prod"},
+			Secret: vaulttypes.Credentials{
+				Login:    "admin",
+				Password: "s3cret",
+				Notes:    "Port: 5432",
+			},
+		},
+		{
+			Name:   "prod-db/cert.pem",
+			Tags:   []string{"db", "prod"},
+			Secret: vaulttypes.Bin{Data: []byte("hello")},
+		},
+		{
+			Name:   "Wiki",
+			Folder: "Work",
+			Secret: vaulttypes.Credentials{
+				Login:    "alice",
+				Password: "pa55",
+				URL:      "https://wiki.example.com",
+			},
+		},
+	}, records)
+}
+
+func TestParse_KeePassXML_UnknownBinary(t *testing.T) {
+	const data = `<KeePassFile><Root><Group><Entry>
+		<String><Key>Title</Key><Value>key</Value></String>
+		<Binary><Key>id_rsa</Key><Value Ref="7"/></Binary>
+	</Entry></Group></Root></KeePassFile>`
+
+	_, err := Parse("keepass-xml", []byte(data))
+	require.ErrorContains(t, err, "unknown binary 7")
+}
+
+func TestParse_1PUX(t *testing.T) {
+	const export = `{"accounts": [{"vaults": [{
+		"attrs": {"name": "Private"},
+		"items": [
+			{
+				"categoryUuid": "001",
+				"overview": {"title": "Mail", "url": "https://mail.example.com", "tags": ["mail"]},
+				"details": {"loginFields": [
+					{"designation": "username", "value": "alice"},
+					{"designation": "password", "value": "s3cret"}
+				]}
+			},
+			{
+				"categoryUuid": "002",
+				"overview": {"title": "Visa"},
+				"details": {"sections": [{"fields": [
+					{"id": "ccnum", "value": {"creditCardNumber": "4111111111111111"}},
+					{"id": "expiry", "value": {"monthYear": 203012}},
+					{"id": "cvv", "value": {"concealed": "123"}},
+					{"id": "cardholder", "value": {"string": "Alice"}}
+				]}]}
+			},
+			{
+				"categoryUuid": "006",
+				"overview": {"title": "Passport"},
+				"details": {"documentAttributes": {"fileName": "passport.pdf", "documentId": "doc1"}}
+			}
+		]
+	}]}]}`
+
+	// Файл с тем же именем из другого документа не должен подставляться
+	records, err := Parse("1password-1pux", new1PUX(t, map[string]string{
+		"export.data":              export,
+		"files/doc2__passport.pdf": "other",
+		"files/doc1__passport.pdf": "pdf",
+		"export.attributes":        "{}",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []Record{
+		{
+			Name:   "Mail",
+			Folder: "Private",
+			Tags:   []string{"mail"},
+			Secret: vaulttypes.Credentials{
+				Login:    "alice",
+				Password: "s3cret",
+				URL:      "https://mail.example.com",
+			},
+		},
+		{
+			Name:   "Visa",
+			Folder: "Private",
+			Secret: vaulttypes.Card{
+				Number:       "4111111111111111",
+				ExpiryDate:   "12/2030",
+				SecurityCode: "123",
+				Holder:       "Alice",
+			},
+		},
+		{
+			Name:   "Passport",
+			Folder: "Private",
+			Secret: vaulttypes.Bin{Data: []byte("pdf")},
+		},
+	}, records)
+}
+
+func TestParse_1PUX_MissingDocument(t *testing.T) {
+	const export = `{"accounts": [{"vaults": [{
+		"attrs": {"name": "Private"},
+		"items": [{
+			"categoryUuid": "006",
+			"overview": {"title": "Passport"},
+			"details": {"documentAttributes": {"fileName": "passport.pdf", "documentId": "doc1"}}
+		}]
+	}]}]}`
+
+	_, err := Parse("1password-1pux", new1PUX(t, map[string]string{
+		"export.data":              export,
+		"files/doc2__passport.pdf": "other",
+		"files/passport.pdf":       "other",
+	}))
+	require.ErrorContains(t, err, "file passport.pdf of document doc1 not found")
+}
+
+func TestParse_1PUX_NotZip(t *testing.T) {
+	_, err := Parse("1password-1pux", []byte("not a zip"))
+	require.ErrorContains(t, err, "1password 1pux")
+}
+
+// new1PUX упаковывает файлы в архив 1PUX
+func new1PUX(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
@@ -0,0 +1,157 @@
+package importer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+type keePassFile struct {
+	Meta struct {
+		RecycleBinUUID string          `xml:"RecycleBinUUID"`
+		Binaries       []keePassBinary `xml:"Binaries>Binary"`
+	} `xml:"Meta"`
+	Root struct {
+		Groups []keePassGroup `xml:"Group"`
+	} `xml:"Root"`
+}
+
+type keePassBinary struct {
+	ID         string `xml:"ID,attr"`
+	Compressed bool   `xml:"Compressed,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type keePassGroup struct {
+	UUID    string         `xml:"UUID"`
+	Name    string         `xml:"Name"`
+	Entries []keePassEntry `xml:"Entry"`
+	Groups  []keePassGroup `xml:"Group"`
+}
+
+type keePassEntry struct {
+	Tags    string `xml:"Tags"`
+	Strings []struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	} `xml:"String"`
+	Binaries []struct {
+		Key   string `xml:"Key"`
+		Value struct {
+			Ref string `xml:"Ref,attr"`
+		} `xml:"Value"`
+	} `xml:"Binary"`
+}
+
+// parseKeePassXML разбирает экспорт KeePass 2.x в формате XML.
+// Корневая группа не попадает в путь папки, корзина пропускается.
+func parseKeePassXML(data []byte) ([]Record, error) {
+	var file keePassFile
+	if err := xml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("keepass xml: %w", err)
+	}
+
+	binaries := make(map[string][]byte, len(file.Meta.Binaries))
+	for _, binary := range file.Meta.Binaries {
+		content, err := decodeKeePassBinary(binary)
+		if err != nil {
+			return nil, fmt.Errorf("keepass xml: binary %s: %w", binary.ID, err)
+		}
+		binaries[binary.ID] = content
+	}
+
+	records := make([]Record, 0)
+	var walk func(group keePassGroup, folder string) error
+	walk = func(group keePassGroup, folder string) error {
+		if group.UUID != "" && group.UUID == file.Meta.RecycleBinUUID {
+			return nil
+		}
+
+		for _, entry := range group.Entries {
+			entryRecords, err := keePassEntryRecords(entry, folder, binaries)
+			if err != nil {
+				return err
+			}
+			records = append(records, entryRecords...)
+		}
+
+		for _, child := range group.Groups {
+			if err := walk(child, path.Join(folder, child.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, root := range file.Root.Groups {
+		if err := walk(root, ""); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+func keePassEntryRecords(entry keePassEntry, folder string, binaries map[string][]byte) ([]Record, error) {
+	values := make(map[string]string)
+	extra := make([][2]string, 0)
+	for _, field := range entry.Strings {
+		switch field.Key {
+		case "Title", "UserName", "Password", "URL", "Notes":
+			values[field.Key] = field.Value
+		default:
+			extra = append(extra, [2]string{field.Key, field.Value})
+		}
+	}
+
+	var tags []string
+	for _, tag := range strings.FieldsFunc(entry.Tags, func(r rune) bool { return r == ';' || r == ',' }) {
+		tags = append(tags, strings.TrimSpace(tag))
+	}
+
+	name := values["Title"]
+	records := []Record{{
+		Name:   name,
+		Folder: folder,
+		Tags:   tags,
+		Secret: newLogin(values["UserName"], values["Password"], values["URL"],
+			extraNotes(values["Notes"], extra)),
+	}}
+
+	for _, attachment := range entry.Binaries {
+		content, ok := binaries[attachment.Value.Ref]
+		if !ok {
+			return nil, fmt.Errorf("keepass xml: entry %s: unknown binary %s", name, attachment.Value.Ref)
+		}
+		records = append(records, Record{
+			Name:   name + "/" + attachment.Key,
+			Folder: folder,
+			Tags:   tags,
+			Secret: vaulttypes.Bin{Data: content},
+		})
+	}
+	return records, nil
+}
+
+func decodeKeePassBinary(binary keePassBinary) ([]byte, error) {
+	content, err := base64.StdEncoding.DecodeString(strings.TrimSpace(binary.Value))
+	if err != nil {
+		return nil, err
+	}
+	if !binary.Compressed {
+		return content, nil
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,202 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+const (
+	onePasswordLogin      = "001"
+	onePasswordCreditCard = "002"
+	onePasswordNote       = "003"
+	onePasswordPassword   = "005"
+	onePasswordDocument   = "006"
+)
+
+type onePasswordExport struct {
+	Accounts []struct {
+		Vaults []struct {
+			Attrs struct {
+				Name string `json:"name"`
+			} `json:"attrs"`
+			Items []onePasswordItem `json:"items"`
+		} `json:"vaults"`
+	} `json:"accounts"`
+}
+
+type onePasswordItem struct {
+	CategoryUUID string `json:"categoryUuid"`
+	Overview     struct {
+		Title string   `json:"title"`
+		URL   string   `json:"url"`
+		Tags  []string `json:"tags"`
+	} `json:"overview"`
+	Details struct {
+		LoginFields []struct {
+			Designation string `json:"designation"`
+			Value       string `json:"value"`
+		} `json:"loginFields"`
+		NotesPlain string `json:"notesPlain"`
+		Password   string `json:"password"`
+		Sections   []struct {
+			Fields []struct {
+				ID    string                     `json:"id"`
+				Title string                     `json:"title"`
+				Value map[string]json.RawMessage `json:"value"`
+			} `json:"fields"`
+		} `json:"sections"`
+		DocumentAttributes *struct {
+			FileName   string `json:"fileName"`
+			DocumentID string `json:"documentId"`
+		} `json:"documentAttributes"`
+	} `json:"details"`
+}
+
+// parse1PUX разбирает архив экспорта 1Password (1PUX).
+// Имя хранилища 1Password становится папкой, вложения — бинарными секретами.
+func parse1PUX(data []byte) ([]Record, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("1password 1pux: %w", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	exportFile, ok := files["export.data"]
+	if !ok {
+		return nil, fmt.Errorf("1password 1pux: export.data not found")
+	}
+	exportData, err := readZipFile(exportFile)
+	if err != nil {
+		return nil, fmt.Errorf("1password 1pux: %w", err)
+	}
+
+	var export onePasswordExport
+	if err := json.Unmarshal(exportData, &export); err != nil {
+		return nil, fmt.Errorf("1password 1pux: %w", err)
+	}
+
+	records := make([]Record, 0)
+	for _, account := range export.Accounts {
+		for _, vault := range account.Vaults {
+			for _, item := range vault.Items {
+				itemRecords, err := onePasswordRecords(item, vault.Attrs.Name, files)
+				if err != nil {
+					return nil, err
+				}
+				records = append(records, itemRecords...)
+			}
+		}
+	}
+	return records, nil
+}
+
+func onePasswordRecords(item onePasswordItem, folder string, files map[string]*zip.File) ([]Record, error) {
+	title := item.Overview.Title
+	record := Record{Name: title, Folder: folder, Tags: item.Overview.Tags}
+
+	fields := make(map[string]string)
+	extra := make([][2]string, 0)
+	for _, section := range item.Details.Sections {
+		for _, field := range section.Fields {
+			value := onePasswordValue(field.Value)
+			fields[field.ID] = value
+			if field.Title != "" {
+				extra = append(extra, [2]string{field.Title, value})
+			}
+		}
+	}
+
+	switch item.CategoryUUID {
+	case onePasswordLogin, onePasswordPassword:
+		var login, password string
+		for _, field := range item.Details.LoginFields {
+			switch field.Designation {
+			case "username":
+				login = field.Value
+			case "password":
+				password = field.Value
+			}
+		}
+		if password == "" {
+			password = item.Details.Password
+		}
+		record.Secret = newLogin(login, password, item.Overview.URL,
+			extraNotes(item.Details.NotesPlain, extra))
+	case onePasswordCreditCard:
+		record.Secret = vaulttypes.Card{
+			Number:       fields["ccnum"],
+			ExpiryDate:   onePasswordExpiry(fields["expiry"]),
+			SecurityCode: fields["cvv"],
+			Holder:       fields["cardholder"],
+			Notes:        item.Details.NotesPlain,
+		}
+	case onePasswordDocument:
+		attrs := item.Details.DocumentAttributes
+		if attrs == nil {
+			return nil, fmt.Errorf("1password 1pux: document %s has no attributes", title)
+		}
+		content, err := onePasswordDocumentContent(files, attrs.DocumentID, attrs.FileName)
+		if err != nil {
+			return nil, fmt.Errorf("1password 1pux: document %s: %w", title, err)
+		}
+		record.Secret = vaulttypes.Bin{Data: content}
+	default:
+		record.Secret = vaulttypes.Text{Data: extraNotes(item.Details.NotesPlain, extra)}
+	}
+	return []Record{record}, nil
+}
+
+// onePasswordValue приводит значение поля 1Password к строке.
+// Значение хранится в объекте с единственным ключом, обозначающим тип поля.
+func onePasswordValue(value map[string]json.RawMessage) string {
+	for _, raw := range value {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+		return strings.Trim(string(raw), `"`)
+	}
+	return ""
+}
+
+// onePasswordExpiry преобразует срок действия карты из вида YYYYMM в MM/YYYY
+func onePasswordExpiry(value string) string {
+	if len(value) != 6 {
+		return value
+	}
+	return value[4:] + "/" + value[:4]
+}
+
+// onePasswordDocumentContent читает файл документа. В 1PUX файл хранится
+// как files/<documentId>__<fileName>, поиск ведется только по этому пути,
+// чтобы файлы с одинаковыми именами в разных документах не путались.
+func onePasswordDocumentContent(files map[string]*zip.File, documentID, fileName string) ([]byte, error) {
+	for _, name := range []string{
+		"files/" + documentID + "__" + fileName,
+		"files/" + documentID,
+	} {
+		if file, ok := files[name]; ok {
+			return readZipFile(file)
+		}
+	}
+	return nil, fmt.Errorf("file %s of document %s not found in archive", fileName, documentID)
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package localsock
+
+// checkDir на этой платформе не поддерживается, доступ к каталогу
+// ограничивается правами, выданными при создании
+func checkDir(dir string) error {
+	return nil
+}
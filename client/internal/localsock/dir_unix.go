@@ -0,0 +1,34 @@
+//go:build unix
+
+package localsock
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// checkDir проверяет, что каталог принадлежит текущему пользователю,
+// и закрывает его от остальных пользователей
+func checkDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return errors.New("not a directory")
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return errors.New("unable to read directory owner")
+	}
+	if int(stat.Uid) != os.Getuid() {
+		return ErrInsecureDir
+	}
+
+	if info.Mode().Perm()&0o077 != 0 {
+		return os.Chmod(dir, 0o700)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package localsock
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// ErrInsecureDir каталог сокета принадлежит другому пользователю
+var ErrInsecureDir = errors.New("socket directory is owned by another user")
+
+// Listen создает Unix-сокет, доступный только текущему пользователю.
+// Сокет создается в каталоге с правами 0700, владелец и права каталога
+// проверяются до создания сокета, поэтому к нему нельзя подключиться
+// до установки прав на сам файл. Кроме того, у каждого подключения
+// проверяется пользователь процесса на другой стороне.
+func Listen(path string) (net.Listener, error) {
+	const op = "localsock.Listen"
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := checkDir(dir); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, dir, err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return peerListener{listener}, nil
+}
+
+// RuntimeDir возвращает каталог сокетов goph-keeper текущего пользователя:
+// XDG_RUNTIME_DIR, а без него отдельный для пользователя каталог во
+// временном каталоге системы
+func RuntimeDir() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "goph-keeper")
+	}
+	return filepath.Join(os.TempDir(), "goph-keeper-"+strconv.Itoa(os.Getuid()))
+}
@@ -0,0 +1,62 @@
+package localsock
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+// ErrForeignPeer к сокету подключился процесс другого пользователя
+var ErrForeignPeer = errors.New("peer belongs to another user")
+
+// peerListener принимает только подключения процессов текущего пользователя
+type peerListener struct {
+	net.Listener
+}
+
+// Accept возвращает следующее подключение текущего пользователя,
+// остальные подключения закрываются
+func (l peerListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPeer(conn); err != nil {
+			conn.Close()
+			continue
+		}
+		return conn, nil
+	}
+}
+
+// checkPeer проверяет, что процесс на другой стороне соединения
+// запущен текущим пользователем
+func checkPeer(conn net.Conn) error {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return ErrForeignPeer
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return err
+	}
+
+	var uid int
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		uid, credErr = peerUID(fd)
+	}); err != nil {
+		return err
+	}
+	if errors.Is(credErr, errPeerUnsupported) {
+		return nil
+	}
+	if credErr != nil {
+		return credErr
+	}
+	if uid != os.Getuid() {
+		return ErrForeignPeer
+	}
+	return nil
+}
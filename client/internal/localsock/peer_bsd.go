@@ -0,0 +1,20 @@
+//go:build darwin || freebsd
+
+package localsock
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+var errPeerUnsupported = errors.New("peer credentials are not supported")
+
+// peerUID возвращает uid процесса на другой стороне сокета (LOCAL_PEERCRED)
+func peerUID(fd uintptr) (int, error) {
+	cred, err := unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	if err != nil {
+		return 0, err
+	}
+	return int(cred.Uid), nil
+}
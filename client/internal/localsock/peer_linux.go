@@ -0,0 +1,18 @@
+package localsock
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+var errPeerUnsupported = errors.New("peer credentials are not supported")
+
+// peerUID возвращает uid процесса на другой стороне сокета (SO_PEERCRED)
+func peerUID(fd uintptr) (int, error) {
+	cred, err := unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	if err != nil {
+		return 0, err
+	}
+	return int(cred.Uid), nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd
+
+package localsock
+
+import "errors"
+
+var errPeerUnsupported = errors.New("peer credentials are not supported")
+
+// peerUID на этой платформе не поддерживается, доступ к сокету
+// ограничивается правами каталога
+func peerUID(fd uintptr) (int, error) {
+	return 0, errPeerUnsupported
+}
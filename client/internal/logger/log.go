@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"log/slog"
 	"os"
 	"sync"
@@ -22,14 +23,14 @@ func GetInstance() *LogInstance {
 		func() {
 			cfg := config.GetInstance().Config
 			log = &LogInstance{
-				Log: setupLogger(cfg.Env),
+				Log: setupLogger(cfg.Env, os.Stdout),
 			}
 		})
 
 	return log
 }
 
-func setupLogger(Env string) *slog.Logger {
+func setupLogger(Env string, w io.Writer) *slog.Logger {
 	const (
 		envDev  = "dev"
 		envProd = "prod"
@@ -40,12 +41,19 @@ func setupLogger(Env string) *slog.Logger {
 	switch Env {
 	case envDev:
 		log = slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}),
+			slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}),
 		)
 	case envProd:
 		log = slog.New(
-			slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
+			slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo}),
 		)
 	}
 	return log
 }
+
+// UseStderr переключает вывод журнала в stderr. Нужен командам,
+// у которых stdout занят протоколом обмена с вызывающей программой.
+func UseStderr() {
+	cfg := config.GetInstance().Config
+	GetInstance().Log = setupLogger(cfg.Env, os.Stderr)
+}
@@ -0,0 +1,186 @@
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+const (
+	KindTOTP = "totp"
+	KindHOTP = "hotp"
+
+	defaultAlgorithm = "SHA1"
+	defaultDigits    = 6
+	defaultPeriod    = 30
+)
+
+var (
+	ErrInvalidURI    = errors.New("invalid otpauth uri")
+	ErrInvalidSecret = errors.New("invalid otp secret")
+)
+
+var algorithms = map[string]func() hash.Hash{
+	"SHA1":   sha1.New,
+	"SHA256": sha256.New,
+	"SHA512": sha512.New,
+}
+
+// ParseURI разбирает ссылку вида otpauth://totp/Issuer:account?secret=...
+func ParseURI(uri string) (vaulttypes.OTP, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return vaulttypes.OTP{}, fmt.Errorf("%w: %w", ErrInvalidURI, err)
+	}
+	if u.Scheme != "otpauth" {
+		return vaulttypes.OTP{}, fmt.Errorf("%w: unexpected scheme %q", ErrInvalidURI, u.Scheme)
+	}
+
+	query := u.Query()
+	secret := vaulttypes.OTP{
+		Kind:      strings.ToLower(u.Host),
+		Secret:    query.Get("secret"),
+		Issuer:    query.Get("issuer"),
+		Algorithm: strings.ToUpper(query.Get("algorithm")),
+	}
+
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		if secret.Issuer == "" {
+			secret.Issuer = strings.TrimSpace(issuer)
+		}
+		secret.Account = strings.TrimSpace(account)
+	} else {
+		secret.Account = label
+	}
+
+	if value := query.Get("digits"); value != "" {
+		if secret.Digits, err = strconv.Atoi(value); err != nil {
+			return vaulttypes.OTP{}, fmt.Errorf("%w: digits: %w", ErrInvalidURI, err)
+		}
+	}
+	if value := query.Get("period"); value != "" {
+		if secret.Period, err = strconv.Atoi(value); err != nil {
+			return vaulttypes.OTP{}, fmt.Errorf("%w: period: %w", ErrInvalidURI, err)
+		}
+	}
+	if value := query.Get("counter"); value != "" {
+		if secret.Counter, err = strconv.ParseUint(value, 10, 64); err != nil {
+			return vaulttypes.OTP{}, fmt.Errorf("%w: counter: %w", ErrInvalidURI, err)
+		}
+	} else if secret.Kind == KindHOTP {
+		return vaulttypes.OTP{}, fmt.Errorf("%w: hotp requires counter", ErrInvalidURI)
+	}
+
+	secret = Normalize(secret)
+	if err := Validate(secret); err != nil {
+		return vaulttypes.OTP{}, err
+	}
+	return secret, nil
+}
+
+// Normalize заполняет параметры по умолчанию
+func Normalize(secret vaulttypes.OTP) vaulttypes.OTP {
+	secret.Kind = strings.ToLower(secret.Kind)
+	secret.Secret = strings.ToUpper(strings.ReplaceAll(secret.Secret, " ", ""))
+	if secret.Algorithm == "" {
+		secret.Algorithm = defaultAlgorithm
+	}
+	secret.Algorithm = strings.ToUpper(secret.Algorithm)
+	if secret.Digits == 0 {
+		secret.Digits = defaultDigits
+	}
+	if secret.Kind == KindTOTP && secret.Period == 0 {
+		secret.Period = defaultPeriod
+	}
+	return secret
+}
+
+// Validate проверяет параметры генерации кодов
+func Validate(secret vaulttypes.OTP) error {
+	if secret.Kind != KindTOTP && secret.Kind != KindHOTP {
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidURI, secret.Kind)
+	}
+	if _, ok := algorithms[secret.Algorithm]; !ok {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidURI, secret.Algorithm)
+	}
+	if secret.Digits < 6 || secret.Digits > 10 {
+		return fmt.Errorf("%w: digits must be between 6 and 10", ErrInvalidURI)
+	}
+	if secret.Kind == KindTOTP && secret.Period <= 0 {
+		return fmt.Errorf("%w: period must be positive", ErrInvalidURI)
+	}
+	if _, err := decodeSecret(secret.Secret); err != nil {
+		return err
+	}
+	return nil
+}
+
+// TOTP возвращает код для момента времени t и число секунд до смены кода
+func TOTP(secret vaulttypes.OTP, t time.Time) (code string, remaining int, err error) {
+	secret = Normalize(secret)
+	if err := Validate(secret); err != nil {
+		return "", 0, err
+	}
+
+	period := int64(secret.Period)
+	counter := uint64(t.Unix() / period)
+	remaining = int(period - t.Unix()%period)
+
+	code, err = generate(secret, counter)
+	return code, remaining, err
+}
+
+// HOTP возвращает код для текущего значения счетчика
+func HOTP(secret vaulttypes.OTP) (string, error) {
+	secret = Normalize(secret)
+	if err := Validate(secret); err != nil {
+		return "", err
+	}
+	return generate(secret, secret.Counter)
+}
+
+// generate вычисляет код по RFC 4226
+func generate(secret vaulttypes.OTP, counter uint64) (string, error) {
+	key, err := decodeSecret(secret.Secret)
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(algorithms[secret.Algorithm], key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint64(1)
+	for i := 0; i < secret.Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", secret.Digits, uint64(value)%mod), nil
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.TrimRight(strings.ToUpper(strings.ReplaceAll(secret, " ", "")), "=")
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+	return key, nil
+}
@@ -0,0 +1,173 @@
+package otp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+func encodeSeed(seed string) string {
+	return base32.StdEncoding.EncodeToString([]byte(seed))
+}
+
+// RFC 4226, Appendix D
+func TestHOTP_RFC4226(t *testing.T) {
+	codes := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+
+	for counter, expected := range codes {
+		code, err := HOTP(vaulttypes.OTP{
+			Kind:    KindHOTP,
+			Secret:  encodeSeed("12345678901234567890"),
+			Counter: uint64(counter),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, code, "counter %d", counter)
+	}
+}
+
+// RFC 6238, Appendix B
+func TestTOTP_RFC6238(t *testing.T) {
+	seeds := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": strings.Repeat("1234567890", 6) + "1234",
+	}
+
+	tests := []struct {
+		unix      int64
+		algorithm string
+		expected  string
+	}{
+		{unix: 59, algorithm: "SHA1", expected: "94287082"},
+		{unix: 59, algorithm: "SHA256", expected: "46119246"},
+		{unix: 59, algorithm: "SHA512", expected: "90693936"},
+		{unix: 1111111109, algorithm: "SHA1", expected: "07081804"},
+		{unix: 1111111109, algorithm: "SHA256", expected: "68084774"},
+		{unix: 1111111109, algorithm: "SHA512", expected: "25091201"},
+		{unix: 1111111111, algorithm: "SHA1", expected: "14050471"},
+		{unix: 1111111111, algorithm: "SHA256", expected: "67062674"},
+		{unix: 1111111111, algorithm: "SHA512", expected: "99943326"},
+		{unix: 1234567890, algorithm: "SHA1", expected: "89005924"},
+		{unix: 1234567890, algorithm: "SHA256", expected: "91819424"},
+		{unix: 1234567890, algorithm: "SHA512", expected: "93441116"},
+		{unix: 2000000000, algorithm: "SHA1", expected: "69279037"},
+		{unix: 2000000000, algorithm: "SHA256", expected: "90698825"},
+		{unix: 2000000000, algorithm: "SHA512", expected: "38618901"},
+		{unix: 20000000000, algorithm: "SHA1", expected: "65353130"},
+		{unix: 20000000000, algorithm: "SHA256", expected: "77737706"},
+		{unix: 20000000000, algorithm: "SHA512", expected: "47863826"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm+"/"+time.Unix(tt.unix, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			code, remaining, err := TOTP(vaulttypes.OTP{
+				Kind:      KindTOTP,
+				Secret:    encodeSeed(seeds[tt.algorithm]),
+				Algorithm: tt.algorithm,
+				Digits:    8,
+			}, time.Unix(tt.unix, 0))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+			assert.Equal(t, int(30-tt.unix%30), remaining)
+		})
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	tests := []struct {
+		name        string
+		uri         string
+		expected    vaulttypes.OTP
+		expectedErr error
+	}{
+		{
+			name: "TOTP with defaults",
+			uri:  "otpauth://totp/ACME:alice@example.com?secret=jbswy3dpehpk3pxp&issuer=ACME",
+			expected: vaulttypes.OTP{
+				Kind:      KindTOTP,
+				Issuer:    "ACME",
+				Account:   "alice@example.com",
+				Secret:    "JBSWY3DPEHPK3PXP",
+				Algorithm: "SHA1",
+				Digits:    6,
+				Period:    30,
+			},
+		},
+		{
+			name: "Issuer from label",
+			uri:  "otpauth://totp/ACME:bob?secret=JBSWY3DPEHPK3PXP&algorithm=sha256&digits=8&period=60",
+			expected: vaulttypes.OTP{
+				Kind:      KindTOTP,
+				Issuer:    "ACME",
+				Account:   "bob",
+				Secret:    "JBSWY3DPEHPK3PXP",
+				Algorithm: "SHA256",
+				Digits:    8,
+				Period:    60,
+			},
+		},
+		{
+			name: "HOTP with counter",
+			uri:  "otpauth://hotp/bob?secret=JBSWY3DPEHPK3PXP&counter=7",
+			expected: vaulttypes.OTP{
+				Kind:      KindHOTP,
+				Account:   "bob",
+				Secret:    "JBSWY3DPEHPK3PXP",
+				Algorithm: "SHA1",
+				Digits:    6,
+				Counter:   7,
+			},
+		},
+		{
+			name:        "HOTP without counter",
+			uri:         "otpauth://hotp/bob?secret=JBSWY3DPEHPK3PXP",
+			expectedErr: ErrInvalidURI,
+		},
+		{
+			name:        "Wrong scheme",
+			uri:         "https://totp/bob?secret=JBSWY3DPEHPK3PXP",
+			expectedErr: ErrInvalidURI,
+		},
+		{
+			name:        "Unsupported algorithm",
+			uri:         "otpauth://totp/bob?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+			expectedErr: ErrInvalidURI,
+		},
+		{
+			name:        "Too few digits",
+			uri:         "otpauth://totp/bob?secret=JBSWY3DPEHPK3PXP&digits=4",
+			expectedErr: ErrInvalidURI,
+		},
+		{
+			name:        "Invalid secret",
+			uri:         "otpauth://totp/bob?secret=not-base32",
+			expectedErr: ErrInvalidSecret,
+		},
+		{
+			name:        "Empty secret",
+			uri:         "otpauth://totp/bob",
+			expectedErr: ErrInvalidSecret,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := ParseURI(tt.uri)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, secret)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format формат вывода команд клиента
+type Format string
+
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	Env   Format = "env"
+)
+
+// Formats возвращает названия поддерживаемых форматов
+func Formats() []string {
+	return []string{string(Table), string(JSON), string(YAML), string(Env)}
+}
+
+// Parse проверяет название формата
+func Parse(s string) (Format, error) {
+	switch format := Format(strings.ToLower(s)); format {
+	case Table, JSON, YAML, Env:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown output format %q, expected one of: %s", s, strings.Join(Formats(), ", "))
+}
+
+// ErrUnsupported возвращается, если команда не может вывести данные в формате
+type ErrUnsupported struct {
+	Format Format
+}
+
+func (e ErrUnsupported) Error() string {
+	return fmt.Sprintf("output format %s is not supported by this command", e.Format)
+}
+
+// Field поле объекта
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Object объект с сохранением порядка полей
+type Object []Field
+
+// Get возвращает значение поля
+func (o Object) Get(key string) (any, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON кодирует объект, сохраняя порядок полей
+func (o Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Key, err)
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Write выводит значение в формате JSON или YAML.
+// YAML строится из JSON, поэтому учитываются теги json и порядок полей.
+func Write(w io.Writer, format Format, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case JSON:
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case YAML:
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		plain(&node)
+
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+	return ErrUnsupported{Format: format}
+}
+
+// plain убирает стиль JSON у узлов: кавычки остаются только там,
+// где без них изменится тип значения
+func plain(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		plain(child)
+	}
+}
+
+// WriteEnv выводит поля объекта как присваивания переменных окружения
+// в синтаксисе shell: PREFIX_KEY='value'. Вложенные объекты получают
+// префикс по имени поля, списки выводятся через запятую.
+func WriteEnv(w io.Writer, prefix string, o Object) error {
+	for _, field := range o {
+		name := field.Key
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		switch value := field.Value.(type) {
+		case Object:
+			if err := WriteEnv(w, name, value); err != nil {
+				return err
+			}
+			continue
+		case map[string]string:
+			keys := make([]string, 0, len(value))
+			for key := range value {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			nested := make(Object, 0, len(keys))
+			for _, key := range keys {
+				nested = append(nested, Field{Key: key, Value: value[key]})
+			}
+			if err := WriteEnv(w, name, nested); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := fmt.Fprintf(w, "%s=%s\n", EnvName(name), ShellQuote(envValue(field.Value))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func envValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case []string:
+		return strings.Join(value, ",")
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// EnvName приводит строку к имени переменной окружения:
+// заглавные латинские буквы, цифры и подчеркивания, не начинается с цифры
+func EnvName(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+// ShellQuote заключает строку в одинарные кавычки для shell
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
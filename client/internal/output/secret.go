@@ -0,0 +1,103 @@
+package output
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// SecretFields возвращает поля секрета с типами по схеме: числовые поля
+// выводятся числами, двоичные в base64. Если names не пуст, возвращаются
+// только перечисленные поля в указанном порядке.
+func SecretFields(secret vaulttypes.Vault, names []string) (Object, error) {
+	schema, ok := vaulttypes.Lookup(secret.Type())
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %s", secret.Type())
+	}
+
+	values, err := vaulttypes.Values(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := schema.Fields
+	if len(names) > 0 {
+		fields = make([]vaulttypes.Field, 0, len(names))
+		for _, name := range names {
+			field, ok := schema.Field(name)
+			if !ok {
+				return nil, unknownField(schema, name)
+			}
+			fields = append(fields, field)
+		}
+	}
+
+	object := make(Object, 0, len(fields))
+	for _, field := range fields {
+		value, ok := values[field.Name]
+		switch {
+		case field.Number && ok:
+			object = append(object, Field{Key: field.Name, Value: json.Number(value)})
+		case field.Number:
+			object = append(object, Field{Key: field.Name, Value: nil})
+		default:
+			object = append(object, Field{Key: field.Name, Value: value})
+		}
+	}
+	return object, nil
+}
+
+// Binary возвращает содержимое двоичного поля секрета. Если имя поля
+// не задано, берется первое двоичное поле типа.
+func Binary(secret vaulttypes.Vault, name string) ([]byte, error) {
+	schema, ok := vaulttypes.Lookup(secret.Type())
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %s", secret.Type())
+	}
+
+	var field vaulttypes.Field
+	if name == "" {
+		for _, f := range schema.Fields {
+			if f.Binary {
+				field, ok = f, true
+				break
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("secret of type %s has no binary fields", secret.Type())
+		}
+	} else if field, ok = schema.Field(name); !ok {
+		return nil, unknownField(schema, name)
+	}
+
+	values, err := vaulttypes.Values(secret)
+	if err != nil {
+		return nil, err
+	}
+	if !field.Binary {
+		return []byte(values[field.Name]), nil
+	}
+	return base64.StdEncoding.DecodeString(values[field.Name])
+}
+
+// IsBinary проверяет, что поле секрета двоичное
+func IsBinary(secret vaulttypes.Vault, name string) bool {
+	schema, ok := vaulttypes.Lookup(secret.Type())
+	if !ok {
+		return false
+	}
+	field, ok := schema.Field(name)
+	return ok && field.Binary
+}
+
+func unknownField(schema vaulttypes.Schema, name string) error {
+	names := make([]string, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		names = append(names, field.Name)
+	}
+	return fmt.Errorf("secret of type %s has no field %q, available: %s",
+		schema.Type, name, strings.Join(names, ", "))
+}
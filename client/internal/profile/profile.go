@@ -0,0 +1,196 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ajugalushkin/goph-keeper/client/config"
+)
+
+// EnvName переменная окружения с именем профиля
+const EnvName = "GOPHKEEPER_PROFILE"
+
+var (
+	ErrNotFound = errors.New("profile not found")
+	ErrExists   = errors.New("profile already exists")
+	// ErrReadOnly профиль задан в файле конфигурации клиента
+	// и не может быть изменен командами
+	ErrReadOnly = errors.New("profile is defined in the config file")
+)
+
+// имя профиля используется в имени файла токена, а ключи конфигурации
+// приводятся viper к нижнему регистру
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// ValidateName проверяет имя профиля
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+// Source источник профиля
+type Source string
+
+const (
+	// SourceConfig профиль из файла конфигурации клиента
+	SourceConfig Source = "config"
+	// SourceFile профиль, добавленный командой profile add
+	SourceFile Source = "file"
+)
+
+// Store профили, добавленные командами клиента, и выбранный профиль.
+// Хранится в каталоге конфигурации пользователя.
+type Store struct {
+	path     string
+	Current  string                    `yaml:"current,omitempty"`
+	Profiles map[string]config.Profile `yaml:"profiles,omitempty"`
+}
+
+// DefaultPath возвращает путь к файлу профилей в каталоге конфигурации пользователя
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goph-keeper", "profiles.yaml"), nil
+}
+
+// Open читает профили из файла. Отсутствующий файл означает пустой список.
+func Open(path string) (*Store, error) {
+	const op = "profile.Open"
+
+	s := &Store{
+		path:     path,
+		Profiles: make(map[string]config.Profile),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if s.Profiles == nil {
+		s.Profiles = make(map[string]config.Profile)
+	}
+	return s, nil
+}
+
+// Save записывает профили в файл
+func (s *Store) Save() error {
+	const op = "profile.Store.Save"
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Entry профиль с именем и источником
+type Entry struct {
+	Name   string
+	Source Source
+	config.Profile
+}
+
+// Set объединяет профили файла конфигурации и профили, добавленные
+// командами. Профили файла конфигурации имеют приоритет.
+type Set struct {
+	store  *Store
+	config map[string]config.Profile
+	// defaultName профиль по умолчанию из файла конфигурации
+	defaultName string
+}
+
+// NewSet создает набор профилей
+func NewSet(store *Store, cfg config.Config) *Set {
+	return &Set{
+		store:       store,
+		config:      cfg.Profiles,
+		defaultName: cfg.Profile,
+	}
+}
+
+// Store возвращает профили, добавленные командами
+func (s *Set) Store() *Store {
+	return s.store
+}
+
+// Get возвращает профиль по имени
+func (s *Set) Get(name string) (Entry, error) {
+	if p, ok := s.config[name]; ok {
+		return Entry{Name: name, Source: SourceConfig, Profile: p}, nil
+	}
+	if p, ok := s.store.Profiles[name]; ok {
+		return Entry{Name: name, Source: SourceFile, Profile: p}, nil
+	}
+	return Entry{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// List возвращает все профили, отсортированные по имени
+func (s *Set) List() []Entry {
+	entries := make([]Entry, 0, len(s.config)+len(s.store.Profiles))
+	for name, p := range s.config {
+		entries = append(entries, Entry{Name: name, Source: SourceConfig, Profile: p})
+	}
+	for name, p := range s.store.Profiles {
+		if _, ok := s.config[name]; ok {
+			continue
+		}
+		entries = append(entries, Entry{Name: name, Source: SourceFile, Profile: p})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// Current возвращает имя выбранного профиля: явно заданное имя,
+// затем переменную окружения GOPHKEEPER_PROFILE, профиль, выбранный
+// командой profile use, и профиль по умолчанию из файла конфигурации.
+// Пустая строка означает, что используется секция client конфигурации.
+func (s *Set) Current(explicit string) string {
+	for _, name := range []string{explicit, os.Getenv(EnvName), s.store.Current, s.defaultName} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// Apply возвращает параметры соединения client, переопределенные профилем
+func Apply(client config.Client, p config.Profile) config.Client {
+	if p.Address != "" {
+		client.Address = p.Address
+	}
+	if p.TLS != (config.TLS{}) {
+		client.TLS = p.TLS
+	}
+	return client
+}
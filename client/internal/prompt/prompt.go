@@ -0,0 +1,82 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var (
+	// ErrNoTerminal возвращается, если запросить значение не у кого:
+	// клиент запущен без управляющего терминала
+	ErrNoTerminal = errors.New("no terminal to prompt for input")
+	// ErrMismatch возвращается, если повторно введенное значение не совпало
+	ErrMismatch = errors.New("values do not match")
+	// ErrEmpty возвращается, если введено пустое значение
+	ErrEmpty = errors.New("empty value")
+)
+
+// Secret запрашивает значение в терминале без отображения ввода.
+// Запрос выводится в управляющий терминал, поэтому stdout и stdin
+// команды могут быть перенаправлены.
+func Secret(label string) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return "", ErrNoTerminal
+		}
+		return read(os.Stdin, os.Stderr, label)
+	}
+	defer tty.Close()
+
+	return read(tty, tty, label)
+}
+
+// SecretConfirm запрашивает значение дважды и проверяет, что оба ввода совпали
+func SecretConfirm(label string) (string, error) {
+	value, err := Secret(label)
+	if err != nil {
+		return "", err
+	}
+	repeat, err := Secret("Repeat " + strings.ToLower(label))
+	if err != nil {
+		return "", err
+	}
+	if value != repeat {
+		return "", ErrMismatch
+	}
+	return value, nil
+}
+
+func read(in *os.File, out io.Writer, label string) (string, error) {
+	fmt.Fprintf(out, "%s: ", label)
+	value, err := term.ReadPassword(int(in.Fd()))
+	fmt.Fprintln(out)
+	if err != nil {
+		return "", err
+	}
+	if len(value) == 0 {
+		return "", ErrEmpty
+	}
+	return string(value), nil
+}
+
+// ReadLine читает значение до конца строки. Завершающий перевод строки
+// отбрасывается, остальные символы сохраняются как есть.
+func ReadLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	if line == "" && errors.Is(err, io.EOF) {
+		return "", ErrEmpty
+	}
+
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
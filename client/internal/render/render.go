@@ -0,0 +1,127 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/secretref"
+)
+
+// Template шаблон конфигурации со ссылками на секреты.
+// Доступные функции:
+//
+//	{{ secret "prod-db" "password" }} значение поля секрета
+//	{{ secret "api-token" }}          значение единственного поля секрета
+//	{{ ref "keeper://prod-db/user" }} значение по ссылке
+type Template struct {
+	name string
+	text string
+}
+
+// Parse разбирает шаблон. Синтаксические ошибки возвращаются сразу,
+// до обращения к серверу.
+func Parse(name, text string) (*Template, error) {
+	t := &Template{name: name, text: text}
+	if _, err := t.parse(collectFuncs(nil)); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Execute выполняет шаблон и пишет результат в w. Все секреты, на которые
+// ссылается шаблон, загружаются одним запросом перед выполнением, каждый
+// секрет запрашивается не больше одного раза. Если секрета или поля нет,
+// возвращается ошибка с местом ссылки в шаблоне, а в w ничего не пишется.
+func (t *Template) Execute(ctx context.Context, resolver *secretref.Resolver, w io.Writer) error {
+	// Первый проход только собирает ссылки. Ошибки выполнения на нем
+	// не важны: они повторятся и будут возвращены на втором проходе.
+	var refs []secretref.Ref
+	collect, err := t.parse(collectFuncs(&refs))
+	if err != nil {
+		return err
+	}
+	_ = collect.Execute(io.Discard, nil)
+
+	if err := resolver.Prefetch(ctx, refs); err != nil {
+		return err
+	}
+
+	tmpl, err := t.parse(resolveFuncs(ctx, resolver))
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, nil); err != nil {
+		return err
+	}
+	_, err = out.WriteTo(w)
+	return err
+}
+
+func (t *Template) parse(funcs template.FuncMap) (*template.Template, error) {
+	return template.New(t.name).Option("missingkey=error").Funcs(funcs).Parse(t.text)
+}
+
+// collectFuncs функции первого прохода: запоминают ссылки и возвращают
+// пустые значения
+func collectFuncs(refs *[]secretref.Ref) template.FuncMap {
+	add := func(ref secretref.Ref) {
+		if refs != nil {
+			*refs = append(*refs, ref)
+		}
+	}
+	return template.FuncMap{
+		"secret": func(item string, field ...string) (string, error) {
+			ref, err := newRef(item, field)
+			if err == nil {
+				add(ref)
+			}
+			return "", err
+		},
+		"ref": func(s string) (string, error) {
+			ref, err := secretref.Parse(s)
+			if err == nil {
+				add(ref)
+			}
+			return "", err
+		},
+	}
+}
+
+// resolveFuncs функции второго прохода: возвращают значения секретов
+func resolveFuncs(ctx context.Context, resolver *secretref.Resolver) template.FuncMap {
+	return template.FuncMap{
+		"secret": func(item string, field ...string) (string, error) {
+			ref, err := newRef(item, field)
+			if err != nil {
+				return "", err
+			}
+			return resolver.Resolve(ctx, ref)
+		},
+		"ref": func(s string) (string, error) {
+			ref, err := secretref.Parse(s)
+			if err != nil {
+				return "", err
+			}
+			return resolver.Resolve(ctx, ref)
+		},
+	}
+}
+
+func newRef(item string, field []string) (secretref.Ref, error) {
+	if item == "" {
+		return secretref.Ref{}, fmt.Errorf("secret name is empty")
+	}
+	switch len(field) {
+	case 0:
+		return secretref.Ref{Item: item}, nil
+	case 1:
+		return secretref.Ref{Item: item, Field: field[0]}, nil
+	default:
+		return secretref.Ref{}, fmt.Errorf("secret %s: expected one field, got %d", item, len(field))
+	}
+}
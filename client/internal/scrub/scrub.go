@@ -0,0 +1,103 @@
+package scrub
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+)
+
+// Mask строка, которой заменяются значения секретов
+const Mask = "*****"
+
+// Writer заменяет значения секретов в потоке вывода.
+// Значение может прийти по частям в нескольких вызовах Write, поэтому
+// конец буфера, совпадающий с началом какого-либо секрета, придерживается
+// до следующей записи или до Flush.
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	secrets [][]byte
+	pending []byte
+}
+
+// NewWriter создает Writer. Пустые значения игнорируются,
+// длинные значения заменяются раньше коротких.
+func NewWriter(w io.Writer, secrets []string) *Writer {
+	values := make([][]byte, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret != "" {
+			values = append(values, []byte(secret))
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	return &Writer{w: w, secrets: values}
+}
+
+// Write записывает данные, заменяя значения секретов
+func (s *Writer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := append(s.pending, p...)
+	out, rest := s.replace(data)
+	s.pending = append([]byte(nil), rest...)
+
+	if _, err := s.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush записывает придержанный остаток
+func (s *Writer) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.pending) == 0 {
+		return nil
+	}
+	_, err := s.w.Write(s.pending)
+	s.pending = nil
+	return err
+}
+
+// replace заменяет значения секретов и возвращает готовый вывод
+// и остаток, который может оказаться началом секрета
+func (s *Writer) replace(data []byte) (out []byte, rest []byte) {
+	out = make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		matched := false
+		for _, secret := range s.secrets {
+			if bytes.HasPrefix(data[i:], secret) {
+				out = append(out, Mask...)
+				i += len(secret)
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		if s.partial(data[i:]) {
+			return out, data[i:]
+		}
+		out = append(out, data[i])
+		i++
+	}
+	return out, nil
+}
+
+// partial проверяет, что данные являются началом одного из секретов
+func (s *Writer) partial(data []byte) bool {
+	for _, secret := range s.secrets {
+		if len(data) < len(secret) && bytes.HasPrefix(secret, data) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package scrub
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		secrets  []string
+		writes   []string
+		expected string
+	}{
+		{
+			name:     "Single write",
+			secrets:  []string{"s3cret"},
+			writes:   []string{"password=s3cret\n"},
+			expected: "password=" + Mask + "\n",
+		},
+		{
+			name:     "Secret split across writes",
+			secrets:  []string{"s3cret"},
+			writes:   []string{"password=s3", "cr", "et\n"},
+			expected: "password=" + Mask + "\n",
+		},
+		{
+			name:     "Longer secret wins",
+			secrets:  []string{"abc", "abcdef"},
+			writes:   []string{"abcdef abc"},
+			expected: Mask + " " + Mask,
+		},
+		{
+			name:     "Partial match at end is flushed",
+			secrets:  []string{"s3cret"},
+			writes:   []string{"value s3c"},
+			expected: "value s3c",
+		},
+		{
+			name:     "Empty secrets are ignored",
+			secrets:  []string{"", "token"},
+			writes:   []string{"no secrets here, token"},
+			expected: "no secrets here, " + Mask,
+		},
+		{
+			name:     "Repeated secret",
+			secrets:  []string{"aa"},
+			writes:   []string{"aaaaa"},
+			expected: Mask + Mask + "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, tt.secrets)
+			for _, write := range tt.writes {
+				n, err := w.Write([]byte(write))
+				require.NoError(t, err)
+				assert.Equal(t, len(write), n)
+			}
+			require.NoError(t, w.Flush())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
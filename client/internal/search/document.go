@@ -0,0 +1,61 @@
+package search
+
+import (
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// SearchableFields возвращает несекретные поля расшифрованного секрета,
+// по которым выполняется поиск. Пароли, номера карт, коды безопасности
+// и двоичные данные не возвращаются, поэтому результат можно хранить
+// в локальном кэше.
+func SearchableFields(secret vaulttypes.Vault) map[string]string {
+	schema, ok := vaulttypes.Lookup(secret.Type())
+	if !ok {
+		return nil
+	}
+	values, err := vaulttypes.Values(secret)
+	if err != nil {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	for _, field := range schema.Fields {
+		if field.Sensitive || field.Binary || field.Number {
+			continue
+		}
+		if value := values[field.Name]; value != "" {
+			fields[field.Name] = value
+		}
+	}
+	return fields
+}
+
+// NewDocument готовит секрет к поиску по метаданным и несекретным полям,
+// полученным из SearchableFields.
+func NewDocument(name, secretType, folder string, tags []string, fields map[string]string) Document {
+	doc := Document{
+		Name: name,
+		Type: secretType,
+		Fields: []Field{
+			{Name: "name", Value: name, Weight: 3},
+			{Name: "folder", Value: folder, Weight: 2},
+			{Name: "type", Value: secretType, Weight: 1},
+		},
+	}
+	for _, tag := range tags {
+		doc.Fields = append(doc.Fields, Field{Name: "tag", Value: tag, Weight: 2})
+	}
+
+	// Поля добавляются в порядке схемы, чтобы при равном счете
+	// результат не зависел от порядка обхода map
+	schema, ok := vaulttypes.Lookup(vaulttypes.VaultType(secretType))
+	if !ok {
+		return doc
+	}
+	for _, field := range schema.Fields {
+		if value, ok := fields[field.Name]; ok {
+			doc.Fields = append(doc.Fields, Field{Name: field.Name, Value: value, Weight: 2})
+		}
+	}
+	return doc
+}
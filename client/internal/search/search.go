@@ -0,0 +1,186 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Field поле секрета, по которому выполняется поиск
+type Field struct {
+	Name   string
+	Value  string
+	Weight int
+}
+
+// Document расшифрованный секрет, подготовленный к поиску
+type Document struct {
+	Name   string
+	Type   string
+	Fields []Field
+}
+
+// Result найденный секрет и поле с лучшим совпадением
+type Result struct {
+	Name    string
+	Type    string
+	Score   int
+	Field   string
+	Matched int
+}
+
+const (
+	scoreExact     = 100
+	scoreSubstring = 60
+	scoreTypo      = 30
+	scoreFuzzy     = 15
+	bonusWordStart = 20
+)
+
+// Find ищет запрос в документах и возвращает совпадения по убыванию релевантности.
+// Запрос разбивается на слова, каждое слово ищется во всех полях. Документы,
+// в которых совпало больше слов, ранжируются выше.
+func Find(query string, docs []Document, limit int) []Result {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	results := make([]Result, 0)
+	for _, doc := range docs {
+		result := Result{Name: doc.Name, Type: doc.Type}
+		bestField := 0
+
+		for _, term := range terms {
+			termScore := 0
+			for _, field := range doc.Fields {
+				score := matchScore(term, strings.ToLower(field.Value)) * field.Weight
+				if score > termScore {
+					termScore = score
+				}
+				if score > bestField {
+					bestField = score
+					result.Field = field.Name
+				}
+			}
+			if termScore > 0 {
+				result.Matched++
+				result.Score += termScore
+			}
+		}
+
+		if result.Matched > 0 {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Matched != results[j].Matched {
+			return results[i].Matched > results[j].Matched
+		}
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Name < results[j].Name
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// matchScore оценивает совпадение слова запроса со значением поля.
+// Проверяются по очереди точное совпадение, вхождение подстроки,
+// опечатка в одну букву и совпадение подпоследовательности символов.
+func matchScore(term, value string) int {
+	if value == "" {
+		return 0
+	}
+	if term == value {
+		return scoreExact
+	}
+
+	if idx := strings.Index(value, term); idx >= 0 {
+		score := scoreSubstring
+		if idx == 0 || !isWordRune(lastRune(value[:idx])) {
+			score += bonusWordStart
+		}
+		return score
+	}
+
+	if utf8.RuneCountInString(term) >= 4 {
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool { return !isWordRune(r) }) {
+			if withinOneEdit(term, word) {
+				return scoreTypo
+			}
+		}
+	}
+
+	return subsequenceScore(term, value)
+}
+
+// subsequenceScore проверяет, что символы слова встречаются в значении по порядку
+// и достаточно плотно, чтобы совпадение не было случайным.
+func subsequenceScore(term, value string) int {
+	termRunes := []rune(term)
+	matched, gaps, pos := 0, 0, -1
+
+	for i, r := range []rune(value) {
+		if matched == len(termRunes) {
+			break
+		}
+		if r != termRunes[matched] {
+			continue
+		}
+		if pos >= 0 {
+			gaps += i - pos - 1
+		}
+		pos = i
+		matched++
+	}
+
+	if matched < len(termRunes) || gaps > len(termRunes) {
+		return 0
+	}
+	return scoreFuzzy
+}
+
+// withinOneEdit проверяет, что строки отличаются не более чем одной правкой
+func withinOneEdit(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	if len(ra)-len(rb) > 1 {
+		return false
+	}
+
+	i, j, edits := 0, 0, 0
+	for i < len(ra) && j < len(rb) {
+		if ra[i] == rb[j] {
+			i++
+			j++
+			continue
+		}
+		edits++
+		if edits > 1 {
+			return false
+		}
+		i++
+		if len(ra) == len(rb) {
+			j++
+		}
+	}
+	return edits+(len(ra)-i) <= 1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
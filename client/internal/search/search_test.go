@@ -0,0 +1,123 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+func TestSearchableFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		secret   vaulttypes.Vault
+		expected map[string]string
+	}{
+		{
+			name: "Credentials without password",
+			secret: vaulttypes.Credentials{
+				Login:    "alice",
+				Password: "s3cret",
+				URL:      "https://example.com",
+			},
+			expected: map[string]string{
+				"login": "alice",
+				"url":   "https://example.com",
+			},
+		},
+		{
+			name: "Card without number and code",
+			secret: vaulttypes.Card{
+				Number:       "4111111111111111",
+				ExpiryDate:   "12/30",
+				SecurityCode: "123",
+				Holder:       "Alice",
+			},
+			expected: map[string]string{
+				"holder": "Alice",
+			},
+		},
+		{
+			name:     "Binary data",
+			secret:   vaulttypes.Bin{Data: []byte("data")},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SearchableFields(tt.secret))
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	docs := []Document{
+		NewDocument("github", "credentials", "work", []string{"dev"},
+			map[string]string{"login": "alice", "url": "https://github.com"}),
+		NewDocument("gitlab", "credentials", "work", nil,
+			map[string]string{"login": "bob"}),
+		NewDocument("bank-card", "card", "personal", []string{"finance"},
+			map[string]string{"holder": "Alice Smith"}),
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "Exact name",
+			query:    "github",
+			expected: []string{"github"},
+		},
+		{
+			name:     "Typo in name",
+			query:    "githib",
+			expected: []string{"github"},
+		},
+		{
+			name:     "Field value",
+			query:    "alice",
+			expected: []string{"github", "bank-card"},
+		},
+		{
+			name:     "More matched words rank higher",
+			query:    "alice finance",
+			expected: []string{"bank-card", "github"},
+		},
+		{
+			name:     "Limit",
+			query:    "work",
+			limit:    1,
+			expected: []string{"github"},
+		},
+		{
+			name:  "Empty query",
+			query: "  ",
+		},
+		{
+			name:  "No match",
+			query: "zzzz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := Find(tt.query, docs, tt.limit)
+
+			names := make([]string, 0, len(results))
+			for _, result := range results {
+				names = append(names, result.Name)
+			}
+			if tt.expected == nil {
+				require.Empty(t, names)
+				return
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
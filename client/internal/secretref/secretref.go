@@ -0,0 +1,165 @@
+package secretref
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// Scheme схема ссылок на секреты
+const Scheme = "keeper://"
+
+var ErrNotFound = errors.New("secret not found")
+
+// Ref ссылка на поле секрета: keeper://<имя секрета>/<поле>.
+// Имя секрета может содержать "/", полем считается последний сегмент.
+// Поле можно не указывать, если у типа секрета единственное поле.
+type Ref struct {
+	Item  string
+	Field string
+}
+
+// IsRef проверяет, что строка является ссылкой на секрет
+func IsRef(s string) bool {
+	return strings.HasPrefix(s, Scheme)
+}
+
+// Parse разбирает ссылку на секрет
+func Parse(s string) (Ref, error) {
+	if !IsRef(s) {
+		return Ref{}, fmt.Errorf("secret reference %q must start with %s", s, Scheme)
+	}
+
+	path := strings.TrimPrefix(s, Scheme)
+	var ref Ref
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		ref.Item, ref.Field = path[:i], path[i+1:]
+	} else {
+		ref.Item = path
+	}
+
+	var err error
+	if ref.Item, err = url.PathUnescape(ref.Item); err != nil {
+		return Ref{}, fmt.Errorf("secret reference %q: %w", s, err)
+	}
+	if ref.Item == "" {
+		return Ref{}, fmt.Errorf("secret reference %q has no secret name", s)
+	}
+	return ref, nil
+}
+
+// String возвращает ссылку в текстовом виде
+func (r Ref) String() string {
+	if r.Field == "" {
+		return Scheme + r.Item
+	}
+	return Scheme + r.Item + "/" + r.Field
+}
+
+// Fetcher загружает секреты по именам. Отсутствующие секреты
+// не попадают в результат.
+type Fetcher func(ctx context.Context, names []string) (map[string]vaulttypes.Vault, error)
+
+// Resolver разрешает ссылки на секреты. Каждый секрет загружается
+// с сервера один раз, повторные ссылки берутся из памяти.
+// Отсутствие секрета тоже запоминается.
+type Resolver struct {
+	fetch Fetcher
+
+	mu      sync.Mutex
+	secrets map[string]vaulttypes.Vault
+}
+
+// NewResolver создает резолвер ссылок
+func NewResolver(fetch Fetcher) *Resolver {
+	return &Resolver{
+		fetch:   fetch,
+		secrets: make(map[string]vaulttypes.Vault),
+	}
+}
+
+// Prefetch загружает одним запросом секреты, на которые указывают ссылки
+func (r *Resolver) Prefetch(ctx context.Context, refs []Ref) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	missing := make(map[string]bool)
+	for _, ref := range refs {
+		if _, ok := r.secrets[ref.Item]; !ok {
+			missing[ref.Item] = true
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	secrets, err := r.fetch(ctx, names)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		r.secrets[name] = secrets[name]
+	}
+	return nil
+}
+
+// Resolve возвращает значение поля секрета
+func (r *Resolver) Resolve(ctx context.Context, ref Ref) (string, error) {
+	if err := r.Prefetch(ctx, []Ref{ref}); err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	secret := r.secrets[ref.Item]
+	r.mu.Unlock()
+	if secret == nil {
+		return "", fmt.Errorf("%s: %w", ref, ErrNotFound)
+	}
+	return Field(secret, ref.Field)
+}
+
+// Field возвращает значение поля секрета по имени поля схемы типа
+func Field(secret vaulttypes.Vault, name string) (string, error) {
+	schema, ok := vaulttypes.Lookup(secret.Type())
+	if !ok {
+		return "", fmt.Errorf("unknown secret type %s", secret.Type())
+	}
+
+	if name == "" {
+		if len(schema.Fields) != 1 {
+			return "", fmt.Errorf("secret of type %s has several fields, specify one of: %s",
+				secret.Type(), fieldNames(schema))
+		}
+		name = schema.Fields[0].Name
+	}
+	if _, ok := schema.Field(name); !ok {
+		return "", fmt.Errorf("secret of type %s has no field %q, available: %s",
+			secret.Type(), name, fieldNames(schema))
+	}
+
+	values, err := vaulttypes.Values(secret)
+	if err != nil {
+		return "", err
+	}
+	return values[name], nil
+}
+
+func fieldNames(schema vaulttypes.Schema) string {
+	names := make([]string, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		names = append(names, field.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,158 @@
+package secretref
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		ref         string
+		expected    Ref
+		expectedErr string
+	}{
+		{
+			name:     "Secret and field",
+			ref:      "keeper://prod-db/password",
+			expected: Ref{Item: "prod-db", Field: "password"},
+		},
+		{
+			name:     "Secret without field",
+			ref:      "keeper://api-token",
+			expected: Ref{Item: "api-token"},
+		},
+		{
+			name:     "Name with slashes",
+			ref:      "keeper://work/prod/db/login",
+			expected: Ref{Item: "work/prod/db", Field: "login"},
+		},
+		{
+			name:     "Escaped name",
+			ref:      "keeper://my%20db/url",
+			expected: Ref{Item: "my db", Field: "url"},
+		},
+		{
+			name:        "Wrong scheme",
+			ref:         "vault://prod-db/password",
+			expectedErr: "must start with keeper://",
+		},
+		{
+			name:        "No secret name",
+			ref:         "keeper:///password",
+			expectedErr: "has no secret name",
+		},
+		{
+			name:        "Invalid escape",
+			ref:         "keeper://bad%zz/password",
+			expectedErr: "invalid URL escape",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := Parse(tt.ref)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestRef_String(t *testing.T) {
+	assert.Equal(t, "keeper://prod-db/password", Ref{Item: "prod-db", Field: "password"}.String())
+	assert.Equal(t, "keeper://api-token", Ref{Item: "api-token"}.String())
+}
+
+func TestField(t *testing.T) {
+	creds := vaulttypes.Credentials{Login: "admin", Password: "s3cret"}
+
+	tests := []struct {
+		name        string
+		secret      vaulttypes.Vault
+		field       string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "Named field",
+			secret:   creds,
+			field:    "password",
+			expected: "s3cret",
+		},
+		{
+			name:     "Single field type",
+			secret:   vaulttypes.Text{Data: "hello"},
+			expected: "hello",
+		},
+		{
+			name:        "Field required",
+			secret:      creds,
+			expectedErr: "has several fields",
+		},
+		{
+			name:        "Unknown field",
+			secret:      creds,
+			field:       "token",
+			expectedErr: `has no field "token"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Field(tt.secret, tt.field)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestResolver(t *testing.T) {
+	var calls [][]string
+	resolver := NewResolver(func(ctx context.Context, names []string) (map[string]vaulttypes.Vault, error) {
+		calls = append(calls, names)
+		return map[string]vaulttypes.Vault{
+			"prod-db": vaulttypes.Credentials{Login: "admin", Password: "s3cret"},
+		}, nil
+	})
+	ctx := context.Background()
+
+	require.NoError(t, resolver.Prefetch(ctx, []Ref{
+		{Item: "prod-db", Field: "login"},
+		{Item: "missing"},
+		{Item: "prod-db", Field: "password"},
+	}))
+
+	value, err := resolver.Resolve(ctx, Ref{Item: "prod-db", Field: "password"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	_, err = resolver.Resolve(ctx, Ref{Item: "missing"})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Найденные и отсутствующие секреты запрашиваются один раз
+	assert.Equal(t, [][]string{{"missing", "prod-db"}}, calls)
+}
+
+func TestResolver_FetchError(t *testing.T) {
+	fetchErr := errors.New("unavailable")
+	resolver := NewResolver(func(ctx context.Context, names []string) (map[string]vaulttypes.Vault, error) {
+		return nil, fetchErr
+	})
+
+	_, err := resolver.Resolve(context.Background(), Ref{Item: "prod-db"})
+	require.ErrorIs(t, err, fetchErr)
+}
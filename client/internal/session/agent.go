@@ -0,0 +1,212 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+)
+
+// Options параметры агента сессии
+type Options struct {
+	// AutoLock время бездействия до блокировки, 0 отключает блокировку
+	AutoLock time.Duration
+	// ScreenLocked проверяет, что экран заблокирован. Если nil,
+	// блокировка экрана не отслеживается.
+	ScreenLocked func() (bool, error)
+	// PollInterval период проверки блокировки экрана
+	PollInterval time.Duration
+	Log          *slog.Logger
+}
+
+// Agent хранит ключ хранилища в памяти, защищенной от выгрузки на диск.
+// Команды клиента проверяют и продлевают сессию через Unix-сокет, сам
+// ключ из агента не передается. После блокировки ключ затирается,
+// а агент завершает работу.
+type Agent struct {
+	opts Options
+
+	mu       sync.Mutex
+	key      []byte
+	timer    *time.Timer
+	expires  time.Time
+	listener net.Listener
+	done     chan struct{}
+}
+
+// New создает агента с ключом key. Ключ копируется в защищенную память,
+// исходный срез затирается.
+func New(key []byte, opts Options) *Agent {
+	locked := make([]byte, len(key))
+	if err := lockMemory(locked); err != nil && opts.Log != nil {
+		opts.Log.Warn("Unable to lock key memory, it may be swapped to disk: ",
+			slog.String("error", err.Error()))
+	}
+	copy(locked, key)
+	wipe(key)
+
+	a := &Agent{
+		opts: opts,
+		key:  locked,
+		done: make(chan struct{}),
+	}
+	if opts.AutoLock > 0 {
+		a.expires = time.Now().Add(opts.AutoLock)
+		a.timer = time.AfterFunc(opts.AutoLock, a.Lock)
+	}
+	return a
+}
+
+// Serve обслуживает запросы до блокировки агента
+func (a *Agent) Serve(listener net.Listener) error {
+	a.mu.Lock()
+	if a.key == nil {
+		a.mu.Unlock()
+		listener.Close()
+		return nil
+	}
+	a.listener = listener
+	a.mu.Unlock()
+
+	if a.opts.ScreenLocked != nil {
+		go a.watchScreen()
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-a.done:
+				return nil
+			default:
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go a.handle(conn)
+	}
+}
+
+// Lock затирает ключ и останавливает агента
+func (a *Agent) Lock() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.key == nil {
+		return
+	}
+	wipe(a.key)
+	_ = unlockMemory(a.key)
+	a.key = nil
+
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	close(a.done)
+	if a.listener != nil {
+		a.listener.Close()
+	}
+	if a.opts.Log != nil {
+		a.opts.Log.Info("Vault locked")
+	}
+}
+
+// Done закрывается после блокировки агента
+func (a *Agent) Done() <-chan struct{} {
+	return a.done
+}
+
+func (a *Agent) handle(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	var req request
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(line, &req); err != nil {
+		writeResponse(conn, response{Error: "invalid request"})
+		return
+	}
+
+	var resp response
+	switch req.Op {
+	case opTouch:
+		resp = a.touchResponse()
+	case opStatus:
+		resp = a.statusResponse()
+	case opLock:
+		a.Lock()
+	default:
+		resp.Error = "unknown operation " + req.Op
+	}
+	writeResponse(conn, resp)
+}
+
+// touchResponse продлевает сессию
+func (a *Agent) touchResponse() response {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.key == nil {
+		return response{Error: ErrLocked.Error()}
+	}
+	if a.timer != nil {
+		a.timer.Reset(a.opts.AutoLock)
+		a.expires = time.Now().Add(a.opts.AutoLock)
+	}
+	return response{ExpiresAt: a.expires}
+}
+
+func (a *Agent) statusResponse() response {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.key == nil {
+		return response{Error: ErrLocked.Error()}
+	}
+	return response{ExpiresAt: a.expires}
+}
+
+// watchScreen блокирует агента при блокировке экрана
+func (a *Agent) watchScreen() {
+	interval := a.opts.PollInterval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.done:
+			return
+		case <-ticker.C:
+			locked, err := a.opts.ScreenLocked()
+			if err != nil {
+				if a.opts.Log != nil {
+					a.opts.Log.Debug("Unable to check screen lock: ", slog.String("error", err.Error()))
+				}
+				continue
+			}
+			if locked {
+				a.Lock()
+				return
+			}
+		}
+	}
+}
+
+func writeResponse(conn net.Conn, resp response) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	_, _ = conn.Write(append(data, '\n'))
+}
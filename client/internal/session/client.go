@@ -0,0 +1,83 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Client клиент агента сессии
+type Client struct {
+	socket string
+}
+
+// NewClient создает клиента агента, слушающего socket
+func NewClient(socket string) *Client {
+	return &Client{socket: socket}
+}
+
+// Touch продлевает сессию и возвращает новое время блокировки
+func (c *Client) Touch() (time.Time, error) {
+	resp, err := c.call(opTouch)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return resp.ExpiresAt, nil
+}
+
+// Status возвращает время блокировки сессии. Нулевое время означает,
+// что сессия не блокируется по бездействию.
+func (c *Client) Status() (time.Time, error) {
+	resp, err := c.call(opStatus)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return resp.ExpiresAt, nil
+}
+
+// Lock блокирует сессию
+func (c *Client) Lock() error {
+	_, err := c.call(opLock)
+	return err
+}
+
+func (c *Client) call(op string) (response, error) {
+	conn, err := net.DialTimeout("unix", c.socket, time.Second)
+	if err != nil {
+		return response{}, ErrNotRunning
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	data, err := json.Marshal(request{Op: op})
+	if err != nil {
+		return response{}, err
+	}
+	if _, err := conn.Write(append(data, '\n')); err != nil {
+		return response{}, fmt.Errorf("session agent: %w", err)
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		// агент закрывает соединение после блокировки
+		if op == opLock {
+			return response{}, nil
+		}
+		return response{}, fmt.Errorf("session agent: %w", err)
+	}
+
+	var resp response
+	if err := json.Unmarshal(line, &resp); err != nil {
+		return response{}, fmt.Errorf("session agent: %w", err)
+	}
+	if resp.Error != "" {
+		if resp.Error == ErrLocked.Error() {
+			return response{}, ErrLocked
+		}
+		return response{}, errors.New(resp.Error)
+	}
+	return resp, nil
+}
@@ -0,0 +1,12 @@
+//go:build !unix
+
+package session
+
+// lockMemory на этой платформе не поддерживается
+func lockMemory(b []byte) error {
+	return nil
+}
+
+func unlockMemory(b []byte) error {
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package session
+
+import "golang.org/x/sys/unix"
+
+// lockMemory запрещает выгрузку памяти ключа на диск
+func lockMemory(b []byte) error {
+	return unix.Mlock(b)
+}
+
+func unlockMemory(b []byte) error {
+	return unix.Munlock(b)
+}
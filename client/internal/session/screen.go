@@ -0,0 +1,31 @@
+package session
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ScreenLockDetector возвращает функцию проверки блокировки экрана или nil,
+// если блокировку нельзя отследить. Поддерживаются сессии systemd-logind.
+func ScreenLockDetector() func() (bool, error) {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	if _, err := exec.LookPath("loginctl"); err != nil {
+		return nil
+	}
+
+	id := os.Getenv("XDG_SESSION_ID")
+	if id == "" {
+		id = "self"
+	}
+	return func() (bool, error) {
+		out, err := exec.Command("loginctl", "show-session", id, "--property=LockedHint", "--value").Output()
+		if err != nil {
+			return false, err
+		}
+		return strings.TrimSpace(string(out)) == "yes", nil
+	}
+}
@@ -0,0 +1,64 @@
+package session
+
+import (
+	"crypto/sha256"
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/localsock"
+)
+
+var (
+	// ErrLocked ключ хранилища недоступен: сессия заблокирована или истекла
+	ErrLocked = errors.New("vault is locked, run \"agent unlock\"")
+	// ErrNotRunning агент сессии не запущен
+	ErrNotRunning = errors.New("session agent is not running")
+)
+
+const (
+	opTouch  = "touch"
+	opStatus = "status"
+	opLock   = "lock"
+)
+
+// параметры argon2id совпадают с параметрами архивов хранилища
+const (
+	kdfTime    = 3
+	kdfMemory  = 64 * 1024
+	kdfThreads = 4
+	keyLen     = 32
+)
+
+// request запрос к агенту сессии, одна строка JSON на соединение
+type request struct {
+	Op string `json:"op"`
+}
+
+// response ответ агента сессии
+type response struct {
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// DeriveKey выводит ключ хранилища из мастер-пароля. Солью служит
+// учетная запись, поэтому ключ одинаков на всех устройствах пользователя.
+func DeriveKey(password []byte, account string) []byte {
+	salt := sha256.Sum256([]byte("goph-keeper:" + strings.ToLower(account)))
+	return argon2.IDKey(password, salt[:], kdfTime, kdfMemory, kdfThreads, keyLen)
+}
+
+// DefaultSocket возвращает путь сокета агента сессии профиля
+func DefaultSocket(profile string) string {
+	return filepath.Join(localsock.RuntimeDir(), "session-"+profile+".sock")
+}
+
+// wipe затирает ключ в памяти
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
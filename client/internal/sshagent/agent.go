@@ -0,0 +1,196 @@
+package sshagent
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/sshkey"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+var (
+	ErrReadOnly      = errors.New("agent: keys are managed by goph-keeper vault")
+	ErrUseNotAllowed = errors.New("agent: key use was not confirmed")
+)
+
+// Key ключ хранилища, обслуживаемый агентом
+type Key struct {
+	Name   string
+	Secret vaulttypes.SSHKey
+}
+
+// ConfirmFunc запрашивает у пользователя разрешение на использование ключа
+type ConfirmFunc func(prompt string) bool
+
+// Agent агент SSH, ключи которого хранятся в хранилище goph-keeper.
+// Добавлять и удалять ключи через протокол агента нельзя.
+type Agent struct {
+	log       *slog.Logger
+	confirm   ConfirmFunc
+	confirmMu sync.Mutex
+
+	mu      sync.RWMutex
+	keyring agent.ExtendedAgent
+	names   map[string]string
+	// lockPass парольная фраза блокировки агента, nil если агент не
+	// заблокирован. Нужна, чтобы перезагрузка ключей не снимала блокировку.
+	lockPass []byte
+}
+
+var _ agent.ExtendedAgent = (*Agent)(nil)
+
+// New создает агента. Если confirm не nil, каждое использование ключа
+// должно быть подтверждено пользователем.
+func New(log *slog.Logger, confirm ConfirmFunc) *Agent {
+	return &Agent{
+		log:     log,
+		confirm: confirm,
+		keyring: agent.NewKeyring().(agent.ExtendedAgent),
+		names:   make(map[string]string),
+	}
+}
+
+// Load заменяет набор ключей агента
+func (a *Agent) Load(keys []Key) error {
+	keyring := agent.NewKeyring().(agent.ExtendedAgent)
+	names := make(map[string]string, len(keys))
+
+	for _, key := range keys {
+		raw, err := sshkey.RawKey(key.Secret)
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key.Name, err)
+		}
+
+		comment := key.Secret.Comment
+		if comment == "" {
+			comment = key.Name
+		}
+		if err := keyring.Add(agent.AddedKey{PrivateKey: raw, Comment: comment}); err != nil {
+			return fmt.Errorf("key %s: %w", key.Name, err)
+		}
+
+		signer, err := ssh.NewSignerFromKey(raw)
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key.Name, err)
+		}
+		names[string(signer.PublicKey().Marshal())] = key.Name
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.lockPass != nil {
+		if err := keyring.Lock(a.lockPass); err != nil {
+			return err
+		}
+	}
+	a.keyring = keyring
+	a.names = names
+	return nil
+}
+
+// Serve обслуживает подключения клиентов до закрытия listener
+func (a *Agent) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		go func() {
+			defer conn.Close()
+			if err := agent.ServeAgent(a, conn); err != nil && !errors.Is(err, net.ErrClosed) {
+				a.log.Debug("agent connection closed", slog.String("error", err.Error()))
+			}
+		}()
+	}
+}
+
+func (a *Agent) List() ([]*agent.Key, error) {
+	return a.current().List()
+}
+
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	a.mu.RLock()
+	keyring, name := a.keyring, a.names[string(key.Marshal())]
+	a.mu.RUnlock()
+
+	if a.confirm != nil {
+		prompt := fmt.Sprintf("Allow use of SSH key %s (%s)?", name, ssh.FingerprintSHA256(key))
+
+		a.confirmMu.Lock()
+		allowed := a.confirm(prompt)
+		a.confirmMu.Unlock()
+
+		if !allowed {
+			a.log.Info("key use denied", slog.String("key", name))
+			return nil, ErrUseNotAllowed
+		}
+	}
+
+	a.log.Info("signing request", slog.String("key", name))
+	return keyring.SignWithFlags(key, data, flags)
+}
+
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	return a.current().Signers()
+}
+
+func (a *Agent) Add(agent.AddedKey) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Remove(ssh.PublicKey) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) RemoveAll() error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Lock(passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.keyring.Lock(passphrase); err != nil {
+		return err
+	}
+	a.lockPass = append([]byte{}, passphrase...)
+	return nil
+}
+
+func (a *Agent) Unlock(passphrase []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if err := a.keyring.Unlock(passphrase); err != nil {
+		return err
+	}
+	for i := range a.lockPass {
+		a.lockPass[i] = 0
+	}
+	a.lockPass = nil
+	return nil
+}
+
+func (a *Agent) Extension(string, []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
+
+func (a *Agent) current() agent.ExtendedAgent {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.keyring
+}
@@ -0,0 +1,34 @@
+package sshagent
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// AskConfirm запрашивает подтверждение через программу SSH_ASKPASS,
+// а если она не задана, то через управляющий терминал.
+func AskConfirm(prompt string) bool {
+	if askpass := os.Getenv("SSH_ASKPASS"); askpass != "" {
+		cmd := exec.Command(askpass, prompt)
+		cmd.Env = append(os.Environ(), "SSH_ASKPASS_PROMPT=confirm")
+		return cmd.Run() == nil
+	}
+
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+
+	fmt.Fprintf(tty, "%s [y/N] ", prompt)
+	answer, err := bufio.NewReader(tty).ReadString('\n')
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
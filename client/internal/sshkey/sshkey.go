@@ -0,0 +1,163 @@
+package sshkey
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+const (
+	KindEd25519 = "ed25519"
+	KindRSA     = "rsa"
+	KindECDSA   = "ecdsa"
+
+	defaultRSABits = 4096
+)
+
+var ErrPassphraseRequired = errors.New("private key is protected by a passphrase")
+
+// Generate создает новую пару ключей указанного вида
+func Generate(kind string, bits int, comment, passphrase string) (vaulttypes.SSHKey, error) {
+	var (
+		key crypto.PrivateKey
+		err error
+	)
+
+	switch strings.ToLower(kind) {
+	case KindEd25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case KindRSA:
+		if bits == 0 {
+			bits = defaultRSABits
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case KindECDSA:
+		key, err = generateECDSA(bits)
+	default:
+		return vaulttypes.SSHKey{}, fmt.Errorf("unsupported key type %q", kind)
+	}
+	if err != nil {
+		return vaulttypes.SSHKey{}, err
+	}
+
+	return fromPrivateKey(key, comment, passphrase)
+}
+
+// Import читает закрытый ключ из файла. Комментарий берется из файла .pub
+// рядом с ключом, если он не задан явно.
+func Import(path, comment, passphrase string) (vaulttypes.SSHKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return vaulttypes.SSHKey{}, err
+	}
+
+	key, err := parse(data, passphrase)
+	if err != nil {
+		return vaulttypes.SSHKey{}, err
+	}
+
+	if comment == "" {
+		if pub, err := os.ReadFile(path + ".pub"); err == nil {
+			if _, pubComment, _, _, err := ssh.ParseAuthorizedKey(pub); err == nil {
+				comment = pubComment
+			}
+		}
+	}
+
+	return fromPrivateKey(key, comment, passphrase)
+}
+
+// Signer возвращает подписывающий ключ для хранимого секрета
+func Signer(secret vaulttypes.SSHKey) (ssh.Signer, error) {
+	key, err := parse([]byte(secret.PrivateKey), secret.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.NewSignerFromKey(key)
+}
+
+// RawKey возвращает закрытый ключ хранимого секрета
+func RawKey(secret vaulttypes.SSHKey) (crypto.PrivateKey, error) {
+	return parse([]byte(secret.PrivateKey), secret.Passphrase)
+}
+
+func parse(data []byte, passphrase string) (crypto.PrivateKey, error) {
+	var (
+		key any
+		err error
+	)
+	if passphrase != "" {
+		key, err = ssh.ParseRawPrivateKeyWithPassphrase(data, []byte(passphrase))
+	} else {
+		key, err = ssh.ParseRawPrivateKey(data)
+	}
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, ErrPassphraseRequired
+		}
+		return nil, err
+	}
+
+	// Ключи OpenSSH ed25519 разбираются в указатель, остальной код ожидает значение.
+	if ed, ok := key.(*ed25519.PrivateKey); ok {
+		return *ed, nil
+	}
+	return key, nil
+}
+
+func fromPrivateKey(key crypto.PrivateKey, comment, passphrase string) (vaulttypes.SSHKey, error) {
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return vaulttypes.SSHKey{}, err
+	}
+
+	var block *pem.Block
+	if passphrase != "" {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, comment, []byte(passphrase))
+	} else {
+		block, err = ssh.MarshalPrivateKey(key, comment)
+	}
+	if err != nil {
+		return vaulttypes.SSHKey{}, err
+	}
+
+	publicKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+	if comment != "" {
+		publicKey += " " + comment
+	}
+
+	return vaulttypes.SSHKey{
+		PrivateKey: string(pem.EncodeToMemory(block)),
+		PublicKey:  publicKey,
+		Comment:    comment,
+		Passphrase: passphrase,
+	}, nil
+}
+
+func generateECDSA(bits int) (crypto.PrivateKey, error) {
+	var curve elliptic.Curve
+	switch bits {
+	case 0, 256:
+		curve = elliptic.P256()
+	case 384:
+		curve = elliptic.P384()
+	case 521:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported ecdsa key size %d", bits)
+	}
+	return ecdsa.GenerateKey(curve, rand.Reader)
+}
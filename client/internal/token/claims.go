@@ -0,0 +1,24 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrNoEmail = errors.New("token has no email")
+
+// Email возвращает адрес пользователя, которому выдан токен.
+// Подпись токена не проверяется: ее проверяет сервер.
+func Email(accessToken string) (string, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(accessToken, claims); err != nil {
+		return "", err
+	}
+
+	email, ok := claims["email"].(string)
+	if !ok || email == "" {
+		return "", ErrNoEmail
+	}
+	return email, nil
+}
@@ -0,0 +1,214 @@
+package token
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound токен не сохранен: пользователь не выполнил вход
+var ErrNotFound = errors.New("not logged in, run \"auth login\"")
+
+// DefaultProfile профиль, используемый без явного выбора
+const DefaultProfile = "default"
+
+const (
+	// tokenKeyName имя ключа шифрования токенов в хранилище ключей
+	tokenKeyName = "token"
+	// tokenMagic заголовок файла с зашифрованным токеном
+	tokenMagic = "GKT1"
+)
+
+// EncryptedStorage хранит токены профилей в каталоге конфигурации
+// пользователя, зашифрованными AES-GCM. Ключ шифрования хранится
+// отдельно от токенов в хранилище ключей Keyring.
+type EncryptedStorage struct {
+	// Dir каталог хранилища, по умолчанию DefaultDir
+	Dir string
+	// Profile имя профиля сервера, по умолчанию DefaultProfile
+	Profile string
+	// Keyring хранилище ключа шифрования, по умолчанию DefaultKeyring
+	Keyring Keyring
+}
+
+var _ Storage = (*EncryptedStorage)(nil)
+
+// NewEncryptedStorage создает хранилище токена профиля profile
+// в каталоге по умолчанию
+func NewEncryptedStorage(profile string) *EncryptedStorage {
+	return &EncryptedStorage{Profile: profile}
+}
+
+// DefaultDir возвращает каталог клиента в каталоге конфигурации пользователя
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goph-keeper"), nil
+}
+
+// Save шифрует токен и записывает его в файл профиля
+func (s *EncryptedStorage) Save(accessToken string) error {
+	const op = "token.EncryptedStorage.Save"
+
+	path, aead, err := s.open(true)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	data := append([]byte(tokenMagic), nonce...)
+	data = aead.Seal(data, nonce, []byte(accessToken), []byte(s.profile()))
+	if err := writePrivate(path, data); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Load читает и расшифровывает токен профиля. Если токен не сохранен,
+// возвращается ErrNotFound.
+func (s *EncryptedStorage) Load() (string, error) {
+	const op = "token.EncryptedStorage.Load"
+
+	path, err := s.path()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, aead, err := s.open(false)
+	if errors.Is(err, ErrKeyNotFound) {
+		return "", fmt.Errorf("%s: token key is missing, run \"auth login\" again", op)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !bytes.HasPrefix(data, []byte(tokenMagic)) || len(data) < len(tokenMagic)+aead.NonceSize() {
+		return "", fmt.Errorf("%s: %s is not a token file", op, path)
+	}
+	data = data[len(tokenMagic):]
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(s.profile()))
+	if err != nil {
+		return "", fmt.Errorf("%s: token can not be decrypted, run \"auth login\" again", op)
+	}
+	return string(plaintext), nil
+}
+
+// Delete удаляет токен профиля
+func (s *EncryptedStorage) Delete() error {
+	path, err := s.path()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *EncryptedStorage) profile() string {
+	if s.Profile == "" {
+		return DefaultProfile
+	}
+	return s.Profile
+}
+
+func (s *EncryptedStorage) dir() (string, error) {
+	if s.Dir != "" {
+		return s.Dir, nil
+	}
+	return DefaultDir()
+}
+
+func (s *EncryptedStorage) path() (string, error) {
+	dir, err := s.dir()
+	if err != nil {
+		return "", err
+	}
+
+	profile := s.profile()
+	if strings.ContainsAny(profile, `/\`) || profile == "." || profile == ".." {
+		return "", fmt.Errorf("invalid profile name %q", profile)
+	}
+	return filepath.Join(dir, "tokens", profile+".token"), nil
+}
+
+// open возвращает путь к файлу токена и шифр. Если create, отсутствующий
+// ключ шифрования создается.
+func (s *EncryptedStorage) open(create bool) (string, cipher.AEAD, error) {
+	path, err := s.path()
+	if err != nil {
+		return "", nil, err
+	}
+
+	keyring := s.Keyring
+	if keyring == nil {
+		dir, err := s.dir()
+		if err != nil {
+			return "", nil, err
+		}
+		keyring = DefaultKeyring(dir)
+	}
+
+	key, err := Key(keyring, tokenKeyName, create)
+	if err != nil {
+		return "", nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, aead, nil
+}
+
+// writePrivate атомарно записывает файл, доступный только владельцу
+func writePrivate(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
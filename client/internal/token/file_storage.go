@@ -1,17 +1,19 @@
 package token
 
 import (
-	"io"
-	"log/slog"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 )
 
-// FileStorage файловое хранилище для токена
+// FileStorage файловое хранилище для токена. Токен хранится открытым
+// текстом, используйте EncryptedStorage.
 type FileStorage struct {
 	Path string
 }
 
-//var _ Storage = (*FileStorage)(nil)
+var _ Storage = (*FileStorage)(nil)
 
 // NewFileStorage создает новое файловое хранилище для токена
 func NewFileStorage(path string) *FileStorage {
@@ -20,35 +22,53 @@ func NewFileStorage(path string) *FileStorage {
 	}
 }
 
-// Save записывает токен в файл
+// Save записывает токен в файл, доступный только владельцу
 func (s *FileStorage) Save(accessToken string) error {
-	file, err := os.Create(s.Path)
+	return writePrivate(s.Path, []byte(accessToken))
+}
+
+// Load читает токен из файла. Если файла нет, возвращается ErrNotFound.
+func (s *FileStorage) Load() (string, error) {
+	b, err := os.ReadFile(s.Path)
 	if err != nil {
-		return err
+		if errors.Is(err, os.ErrNotExist) {
+			return "", ErrNotFound
+		}
+		return "", err
 	}
+	return strings.TrimSpace(string(b)), nil
+}
 
-	defer func() {
-		if err = file.Close(); err != nil {
-			slog.Error("Error closing file: ", slog.String("error", err.Error()))
-		}
-	}()
+// migratingStorage хранилище, которое при отсутствии токена переносит
+// его из устаревшего файла с открытым текстом
+type migratingStorage struct {
+	Storage
+	legacy *FileStorage
+}
 
-	_, err = file.WriteString(accessToken)
-	return err
+// WithLegacyFile возвращает хранилище s, которое при первом чтении
+// переносит в себя токен из файла path, оставшегося от прежних версий
+// клиента, и удаляет этот файл
+func WithLegacyFile(s Storage, path string) Storage {
+	return migratingStorage{Storage: s, legacy: NewFileStorage(path)}
 }
 
-// Load читает токен из файла
-func (s *FileStorage) Load() (string, error) {
-	file, err := os.Open(s.Path)
-	if err != nil {
-		return "", nil
+// Load читает токен, при необходимости перенося его из устаревшего файла
+func (s migratingStorage) Load() (string, error) {
+	accessToken, err := s.Storage.Load()
+	if !errors.Is(err, ErrNotFound) {
+		return accessToken, err
 	}
-	defer func() {
-		if err = file.Close(); err != nil {
-			slog.Error("Error closing file: ", slog.String("error", err.Error()))
-		}
-	}()
 
-	b, err := io.ReadAll(file)
-	return string(b), err
+	legacyToken, legacyErr := s.legacy.Load()
+	if legacyErr != nil || legacyToken == "" {
+		return "", err
+	}
+	if err := s.Storage.Save(legacyToken); err != nil {
+		return "", fmt.Errorf("migrate %s: %w", s.legacy.Path, err)
+	}
+	if err := os.Remove(s.legacy.Path); err != nil {
+		return "", fmt.Errorf("migrate %s: %w", s.legacy.Path, err)
+	}
+	return legacyToken, nil
 }
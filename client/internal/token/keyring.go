@@ -0,0 +1,193 @@
+package token
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+var (
+	// ErrKeyNotFound ключ отсутствует в хранилище ключей
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrNoKeyring системное хранилище ключей недоступно
+	ErrNoKeyring = errors.New("no system keyring found")
+)
+
+// keyringService имя сервиса, под которым ключи хранятся в системном хранилище
+const keyringService = "goph-keeper"
+
+// Keyring хранилище ключей клиента
+type Keyring interface {
+	// Get возвращает ключ или ErrKeyNotFound
+	Get(name string) ([]byte, error)
+	// Set сохраняет ключ, заменяя прежний
+	Set(name string, key []byte) error
+}
+
+// DefaultKeyring возвращает системное хранилище ключей, а если
+// оно недоступно, файлы в каталоге dir
+func DefaultKeyring(dir string) Keyring {
+	file := FileKeyring{Dir: dir}
+	system, err := SystemKeyring()
+	if err != nil {
+		return file
+	}
+	return fallbackKeyring{primary: system, secondary: file}
+}
+
+// Key возвращает ключ name из хранилища ключей. Если ключа нет и create,
+// создается и сохраняется случайный ключ AES-256.
+func Key(keyring Keyring, name string, create bool) ([]byte, error) {
+	key, err := keyring.Get(name)
+	if errors.Is(err, ErrKeyNotFound) && create {
+		key = make([]byte, 32)
+		if _, err := io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+		err = keyring.Set(name, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// SystemKeyring возвращает системное хранилище ключей: связку ключей
+// macOS через security или Secret Service через secret-tool
+func SystemKeyring() (Keyring, error) {
+	name := "secret-tool"
+	if runtime.GOOS == "darwin" {
+		name = "security"
+	}
+	if runtime.GOOS == "windows" {
+		return nil, ErrNoKeyring
+	}
+	if _, err := exec.LookPath(name); err != nil {
+		return nil, ErrNoKeyring
+	}
+	if name == "security" {
+		return macKeyring{}, nil
+	}
+	return secretService{}, nil
+}
+
+// secretService хранилище ключей Secret Service (GNOME Keyring, KWallet)
+type secretService struct{}
+
+// Get возвращает ключ из Secret Service
+func (secretService) Get(name string) ([]byte, error) {
+	cmd := exec.Command("secret-tool", "lookup", "service", keyringService, "name", name)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// secret-tool завершается с кодом 1 без сообщения, если ключа нет
+		if stderr.Len() == 0 {
+			return nil, ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("secret-tool: %s", strings.TrimSpace(stderr.String()))
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+}
+
+// Set сохраняет ключ в Secret Service. Ключ передается через stdin,
+// чтобы не попасть в список процессов.
+func (secretService) Set(name string, key []byte) error {
+	cmd := exec.Command("secret-tool", "store",
+		"--label", keyringService+" "+name,
+		"service", keyringService, "name", name)
+	cmd.Stdin = strings.NewReader(base64.StdEncoding.EncodeToString(key))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("secret-tool: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// macKeyring связка ключей macOS
+type macKeyring struct{}
+
+// Get возвращает ключ из связки ключей
+func (macKeyring) Get(name string) ([]byte, error) {
+	out, err := exec.Command("security", "find-generic-password",
+		"-s", keyringService, "-a", name, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		// 44 errSecItemNotFound
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 44 {
+			return nil, ErrKeyNotFound
+		}
+		return nil, fmt.Errorf("security: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+}
+
+// Set сохраняет ключ в связке ключей. Команда передается в
+// интерактивном режиме через stdin, чтобы ключ не попал в список процессов.
+func (macKeyring) Set(name string, key []byte) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		keyringService, name, base64.StdEncoding.EncodeToString(key)))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("security: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// FileKeyring хранит ключи в файлах, доступных только владельцу.
+// Используется, если системное хранилище ключей недоступно.
+type FileKeyring struct {
+	Dir string
+}
+
+// Get читает ключ из файла
+func (k FileKeyring) Get(name string) ([]byte, error) {
+	data, err := os.ReadFile(k.path(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+}
+
+// Set записывает ключ в файл
+func (k FileKeyring) Set(name string, key []byte) error {
+	return writePrivate(k.path(name), []byte(base64.StdEncoding.EncodeToString(key)))
+}
+
+func (k FileKeyring) path(name string) string {
+	return filepath.Join(k.Dir, name+".key")
+}
+
+// fallbackKeyring хранилище ключей с запасным вариантом на случай,
+// если основное недоступно, например нет сессии D-Bus
+type fallbackKeyring struct {
+	primary   Keyring
+	secondary Keyring
+}
+
+// Get ищет ключ в основном хранилище, затем в запасном
+func (k fallbackKeyring) Get(name string) ([]byte, error) {
+	key, err := k.primary.Get(name)
+	if err == nil {
+		return key, nil
+	}
+	return k.secondary.Get(name)
+}
+
+// Set сохраняет ключ в основное хранилище, а при ошибке в запасное
+func (k fallbackKeyring) Set(name string, key []byte) error {
+	if err := k.primary.Set(name, key); err != nil {
+		return k.secondary.Set(name, key)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package token
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmail(t *testing.T) {
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "Email claim",
+			token:    sign(jwt.MapClaims{"uid": 1, "email": "alice@example.com"}),
+			expected: "alice@example.com",
+		},
+		{
+			name:        "No email claim",
+			token:       sign(jwt.MapClaims{"uid": 1}),
+			expectedErr: ErrNoEmail,
+		},
+		{
+			name:        "Empty email claim",
+			token:       sign(jwt.MapClaims{"email": ""}),
+			expectedErr: ErrNoEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := Email(tt.token)
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, email)
+		})
+	}
+
+	_, err := Email("not a token")
+	require.Error(t, err)
+}
+
+func newTestStorage(t *testing.T, dir, profile string) *EncryptedStorage {
+	t.Helper()
+	return &EncryptedStorage{
+		Dir:     dir,
+		Profile: profile,
+		Keyring: FileKeyring{Dir: dir},
+	}
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	dir := t.TempDir()
+	work := newTestStorage(t, dir, "work")
+	home := newTestStorage(t, dir, "")
+
+	_, err := work.Load()
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, work.Save("work-token"))
+	require.NoError(t, home.Save("home-token"))
+
+	accessToken, err := work.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "work-token", accessToken)
+
+	accessToken, err = home.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "home-token", accessToken)
+
+	path := filepath.Join(dir, "tokens", "work.token")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "work-token")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, work.Delete())
+	_, err = work.Load()
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, work.Delete())
+}
+
+func TestEncryptedStorage_FailCases(t *testing.T) {
+	dir := t.TempDir()
+	storage := newTestStorage(t, dir, "work")
+	require.NoError(t, storage.Save("work-token"))
+
+	tests := []struct {
+		name        string
+		prepare     func(t *testing.T) *EncryptedStorage
+		expectedErr string
+	}{
+		{
+			name: "Invalid profile name",
+			prepare: func(t *testing.T) *EncryptedStorage {
+				return newTestStorage(t, dir, "../work")
+			},
+			expectedErr: "invalid profile name",
+		},
+		{
+			name: "Token copied to another profile",
+			prepare: func(t *testing.T) *EncryptedStorage {
+				data, err := os.ReadFile(filepath.Join(dir, "tokens", "work.token"))
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "tokens", "home.token"), data, 0o600))
+				return newTestStorage(t, dir, "home")
+			},
+			expectedErr: "token can not be decrypted",
+		},
+		{
+			name: "Missing key",
+			prepare: func(t *testing.T) *EncryptedStorage {
+				s := newTestStorage(t, dir, "work")
+				s.Keyring = FileKeyring{Dir: t.TempDir()}
+				return s
+			},
+			expectedErr: "token key is missing",
+		},
+		{
+			name: "Not a token file",
+			prepare: func(t *testing.T) *EncryptedStorage {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, "tokens", "plain.token"), []byte("token"), 0o600))
+				return newTestStorage(t, dir, "plain")
+			},
+			expectedErr: "is not a token file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.prepare(t).Load()
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestWithLegacyFile(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "token.txt")
+	require.NoError(t, os.WriteFile(legacy, []byte("legacy-token\n"), 0o600))
+
+	storage := WithLegacyFile(newTestStorage(t, dir, ""), legacy)
+
+	accessToken, err := storage.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", accessToken)
+	assert.NoFileExists(t, legacy)
+
+	accessToken, err = storage.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-token", accessToken)
+
+	_, err = WithLegacyFile(newTestStorage(t, t.TempDir(), ""), legacy).Load()
+	require.ErrorIs(t, err, ErrNotFound)
+}
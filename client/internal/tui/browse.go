@@ -0,0 +1,339 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/search"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+const mask = "••••••••"
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1)
+)
+
+// filter отбирает секреты по строке поиска
+func (m *model) filter() {
+	m.visible = m.visible[:0]
+
+	query := strings.TrimSpace(m.search.Value())
+	if query == "" {
+		for i := range m.entries {
+			m.visible = append(m.visible, i)
+		}
+	} else {
+		index := make(map[string]int, len(m.entries))
+		docs := make([]search.Document, 0, len(m.entries))
+		for i, entry := range m.entries {
+			index[entry.Name] = i
+			docs = append(docs, searchDocument(entry))
+		}
+		for _, result := range search.Find(query, docs, 0) {
+			m.visible = append(m.visible, index[result.Name])
+		}
+	}
+
+	if m.cursor >= len(m.visible) {
+		m.cursor = max(len(m.visible)-1, 0)
+	}
+}
+
+// searchDocument готовит секрет к поиску. Значения секретных полей
+// в поиске не участвуют.
+func searchDocument(entry Entry) search.Document {
+	doc := search.Document{Name: entry.Name, Type: string(entry.Type)}
+	doc.Fields = append(doc.Fields,
+		search.Field{Name: "name", Value: entry.Name, Weight: 3},
+		search.Field{Name: "folder", Value: entry.Folder, Weight: 2},
+		search.Field{Name: "type", Value: string(entry.Type), Weight: 1},
+	)
+	for _, tag := range entry.Tags {
+		doc.Fields = append(doc.Fields, search.Field{Name: "tag", Value: tag, Weight: 2})
+	}
+	if schema, ok := vaulttypes.Lookup(entry.Type); ok {
+		for _, field := range schema.Fields {
+			if field.Sensitive || field.Binary {
+				continue
+			}
+			doc.Fields = append(doc.Fields, search.Field{Name: field.Name, Value: entry.Values[field.Name], Weight: 1})
+		}
+	}
+	return doc
+}
+
+func (m *model) selected() (Entry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return Entry{}, false
+	}
+	return m.entries[m.visible[m.cursor]], true
+}
+
+func (m *model) moveCursor(delta int) {
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.visible)-1, 0))
+	m.field = 0
+	m.revealed = make(map[string]bool)
+}
+
+func (m *model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.searching {
+		switch msg.String() {
+		case "esc":
+			m.search.Reset()
+			m.search.Blur()
+			m.searching = false
+			m.filter()
+			return m, nil
+		case "enter", "down", "tab":
+			m.search.Blur()
+			m.searching = false
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		m.cursor = 0
+		m.filter()
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if m.search.Value() == "" {
+			return m, tea.Quit
+		}
+		m.search.Reset()
+		m.filter()
+	case "/":
+		m.searching = true
+		return m, m.search.Focus()
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.listHeight())
+	case "pgdown":
+		m.moveCursor(m.listHeight())
+	case "home", "g":
+		m.moveCursor(-len(m.visible))
+	case "end", "G":
+		m.moveCursor(len(m.visible))
+	case "enter", "tab", "right", "l":
+		if _, ok := m.selected(); ok {
+			m.mode = modeDetail
+		}
+	case "c":
+		if entry, ok := m.selected(); ok {
+			m.copyField(entry, primaryField(entry))
+		}
+	case "n":
+		m.form = newCreateForm()
+		m.mode = modeForm
+		return m, m.form.focusCmd()
+	case "e":
+		return m, m.edit()
+	case "r":
+		m.status = "Loading..."
+		return m, m.load()
+	case "ctrl+l":
+		m.lock("Locked")
+	}
+	return m, nil
+}
+
+func (m *model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entry, ok := m.selected()
+	if !ok {
+		m.mode = modeList
+		return m, nil
+	}
+	fields := entryFields(entry)
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "tab", "left", "h":
+		m.mode = modeList
+	case "up", "k":
+		m.field = max(m.field-1, 0)
+	case "down", "j":
+		m.field = min(m.field+1, max(len(fields)-1, 0))
+	case "v", " ":
+		if m.field < len(fields) {
+			name := fields[m.field].Name
+			m.revealed[name] = !m.revealed[name]
+		}
+	case "V":
+		reveal := len(m.revealed) == 0
+		m.revealed = make(map[string]bool)
+		if reveal {
+			for _, field := range fields {
+				m.revealed[field.Name] = true
+			}
+		}
+	case "c", "enter":
+		if m.field < len(fields) {
+			m.copyField(entry, fields[m.field].Name)
+		}
+	case "e":
+		return m, m.edit()
+	case "ctrl+l":
+		m.lock("Locked")
+	}
+	return m, nil
+}
+
+func (m *model) edit() tea.Cmd {
+	entry, ok := m.selected()
+	if !ok {
+		return nil
+	}
+	form, err := newEditForm(entry)
+	if err != nil {
+		m.status = err.Error()
+		return nil
+	}
+	m.form = form
+	m.mode = modeForm
+	return m.form.focusCmd()
+}
+
+// entryFields поля схемы типа секрета
+func entryFields(entry Entry) []vaulttypes.Field {
+	schema, ok := vaulttypes.Lookup(entry.Type)
+	if !ok {
+		return nil
+	}
+	return schema.Fields
+}
+
+// primaryField поле, которое копируется из списка: первое заполненное
+// секретное поле, а если таких нет, первое поле
+func primaryField(entry Entry) string {
+	fields := entryFields(entry)
+	for _, field := range fields {
+		if field.Sensitive && !field.Binary && entry.Values[field.Name] != "" {
+			return field.Name
+		}
+	}
+	if len(fields) > 0 {
+		return fields[0].Name
+	}
+	return ""
+}
+
+func (m *model) listHeight() int {
+	return max(m.height-6, 1)
+}
+
+func (m *model) viewBrowse() string {
+	width := max(m.width, 40)
+	listWidth := min(max(width/3, 20), 40)
+	height := m.listHeight()
+
+	header := titleStyle.Render("goph-keeper") + dimStyle.Render("  "+m.store.Account())
+
+	var list strings.Builder
+	list.WriteString(m.search.View() + "\n")
+	start := max(m.cursor-height+2, 0)
+	for i := start; i < len(m.visible) && i-start < height-1; i++ {
+		entry := m.entries[m.visible[i]]
+		line := truncate(entry.Name, listWidth-4)
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		list.WriteString(line + "\n")
+	}
+	if len(m.visible) == 0 && m.status == "" {
+		list.WriteString(dimStyle.Render("no secrets") + "\n")
+	}
+
+	left := paneStyle.Width(listWidth).Height(height).Render(strings.TrimSuffix(list.String(), "\n"))
+	right := paneStyle.Width(max(width-listWidth-4, 20)).Height(height).Render(m.viewDetail(width - listWidth - 8))
+
+	help := "/ search  ↑↓ move  enter details  c copy  n new  e edit  r reload  ctrl+l lock  q quit"
+	if m.mode == modeDetail {
+		help = "↑↓ field  v reveal  V reveal all  c copy  e edit  esc back  ctrl+l lock  q quit"
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.JoinHorizontal(lipgloss.Top, left, right),
+		m.viewStatus(),
+		dimStyle.Render(help),
+	)
+}
+
+func (m *model) viewDetail(width int) string {
+	entry, ok := m.selected()
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(entry.Name) + "\n")
+	meta := string(entry.Type)
+	if entry.Folder != "" {
+		meta += "  folder: " + entry.Folder
+	}
+	if len(entry.Tags) > 0 {
+		meta += "  tags: " + strings.Join(entry.Tags, ", ")
+	}
+	b.WriteString(dimStyle.Render(meta) + "\n\n")
+
+	for i, field := range entryFields(entry) {
+		value := entry.Values[field.Name]
+		switch {
+		case field.Binary && value != "":
+			value = fmt.Sprintf("<binary, %d bytes>", len(value)*3/4)
+		case field.Sensitive && value != "" && !m.revealed[field.Name]:
+			value = mask
+		}
+		line := fmt.Sprintf("%-12s %s", field.Name+":", truncate(value, max(width-13, 8)))
+		if m.mode == modeDetail && i == m.field {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (m *model) viewStatus() string {
+	if strings.HasPrefix(m.status, "Failed") || strings.HasPrefix(m.status, "Unlock failed") {
+		return errorStyle.Render(m.status)
+	}
+	return m.status
+}
+
+func (m *model) viewLocked() string {
+	lines := []string{
+		titleStyle.Render("goph-keeper is locked"),
+		dimStyle.Render(m.store.Account()),
+		"",
+		m.password.View(),
+		"",
+		m.viewStatus(),
+		dimStyle.Render("enter unlock  esc quit"),
+	}
+	return lipgloss.Place(max(m.width, 40), max(m.height, 10), lipgloss.Center, lipgloss.Center,
+		lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// truncate обрезает строку до width символов
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:max(width-1, 0)]) + "…"
+}
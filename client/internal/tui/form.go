@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// form форма создания или редактирования секрета, поля которой
+// строятся по схеме типа. Двоичные поля в форме не редактируются.
+type form struct {
+	// edit редактируемый секрет, nil при создании
+	edit *Entry
+
+	// schemas типы, доступные при создании
+	schemas []vaulttypes.Schema
+	schema  int
+
+	name   textinput.Model
+	fields []vaulttypes.Field
+	inputs []textinput.Model
+
+	// focus номер строки формы: при создании строка 0 это тип,
+	// строка 1 имя, дальше поля; при редактировании только поля
+	focus int
+	err   string
+}
+
+func newCreateForm() *form {
+	f := &form{name: textinput.New()}
+	f.name.Prompt = ""
+	f.name.Placeholder = "secret name"
+
+	for _, schema := range vaulttypes.Schemas() {
+		if editable(schema) {
+			f.schemas = append(f.schemas, schema)
+		}
+	}
+	f.setFields(nil)
+	return f
+}
+
+func newEditForm(entry Entry) (*form, error) {
+	schema, ok := vaulttypes.Lookup(entry.Type)
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %s", entry.Type)
+	}
+	if !editable(schema) {
+		return nil, fmt.Errorf("secret of type %s has binary fields, edit it with the CLI", entry.Type)
+	}
+
+	f := &form{edit: &entry, schemas: []vaulttypes.Schema{schema}}
+	f.setFields(entry.Values)
+	return f, nil
+}
+
+// editable проверяет, что все обязательные поля типа можно ввести в форме
+func editable(schema vaulttypes.Schema) bool {
+	for _, field := range schema.Fields {
+		if field.Binary && field.Required {
+			return false
+		}
+	}
+	return true
+}
+
+// setFields создает поля ввода для выбранного типа
+func (f *form) setFields(values map[string]string) {
+	f.fields, f.inputs = nil, nil
+	if len(f.schemas) == 0 {
+		return
+	}
+
+	for _, field := range f.schemas[f.schema].Fields {
+		if field.Binary {
+			continue
+		}
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = field.Usage
+		input.SetValue(values[field.Name])
+		if field.Sensitive {
+			input.EchoMode = textinput.EchoPassword
+		}
+		f.fields = append(f.fields, field)
+		f.inputs = append(f.inputs, input)
+	}
+}
+
+// firstField номер строки первого поля
+func (f *form) firstField() int {
+	if f.edit != nil {
+		return 0
+	}
+	return 2
+}
+
+func (f *form) rows() int {
+	return f.firstField() + len(f.inputs)
+}
+
+// focusCmd переводит курсор на текущую строку формы
+func (f *form) focusCmd() tea.Cmd {
+	f.name.Blur()
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+
+	switch {
+	case f.edit == nil && f.focus == 1:
+		return f.name.Focus()
+	case f.focus >= f.firstField():
+		return f.inputs[f.focus-f.firstField()].Focus()
+	}
+	return nil
+}
+
+func (m *model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.form
+
+	if f.rows() == 0 || len(f.schemas) == 0 {
+		switch msg.String() {
+		case "esc", "ctrl+s":
+		default:
+			return m, nil
+		}
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.form = nil
+		if f.edit != nil {
+			m.mode = modeDetail
+		} else {
+			m.mode = modeList
+		}
+		return m, nil
+	case "ctrl+s":
+		return m, m.submitForm()
+	case "tab", "down":
+		f.focus = (f.focus + 1) % f.rows()
+		return m, f.focusCmd()
+	case "shift+tab", "up":
+		f.focus = (f.focus + f.rows() - 1) % f.rows()
+		return m, f.focusCmd()
+	case "enter":
+		if f.focus == f.rows()-1 {
+			return m, m.submitForm()
+		}
+		f.focus++
+		return m, f.focusCmd()
+	case "ctrl+r":
+		if i := f.focus - f.firstField(); i >= 0 && f.fields[i].Sensitive {
+			if f.inputs[i].EchoMode == textinput.EchoPassword {
+				f.inputs[i].EchoMode = textinput.EchoNormal
+			} else {
+				f.inputs[i].EchoMode = textinput.EchoPassword
+			}
+		}
+		return m, nil
+	}
+
+	if f.edit == nil && f.focus == 0 {
+		switch msg.String() {
+		case "left", "h":
+			f.schema = (f.schema + len(f.schemas) - 1) % len(f.schemas)
+			f.setFields(nil)
+		case "right", "l", " ":
+			f.schema = (f.schema + 1) % len(f.schemas)
+			f.setFields(nil)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	if f.edit == nil && f.focus == 1 {
+		f.name, cmd = f.name.Update(msg)
+	} else {
+		i := f.focus - f.firstField()
+		f.inputs[i], cmd = f.inputs[i].Update(msg)
+	}
+	return m, cmd
+}
+
+// submitForm проверяет форму и сохраняет секрет
+func (m *model) submitForm() tea.Cmd {
+	f := m.form
+	if len(f.schemas) == 0 {
+		f.err = "no secret types can be created here"
+		return nil
+	}
+	schema := f.schemas[f.schema]
+
+	values := make(map[string]string, len(schema.Fields))
+	if f.edit != nil {
+		// двоичные поля в форме не показываются и сохраняются как были
+		for _, field := range schema.Fields {
+			if field.Binary {
+				values[field.Name] = f.edit.Values[field.Name]
+			}
+		}
+	}
+	for i, field := range f.fields {
+		values[field.Name] = f.inputs[i].Value()
+	}
+
+	secret, err := vaulttypes.FromValues(schema.Type, values)
+	if err != nil {
+		f.err = err.Error()
+		return nil
+	}
+
+	if f.edit != nil {
+		entry := *f.edit
+		return func() tea.Msg {
+			return savedMsg{name: entry.Name, err: m.store.Update(context.Background(), entry, secret)}
+		}
+	}
+
+	name := strings.TrimSpace(f.name.Value())
+	if name == "" {
+		f.err = "secret name is required"
+		return nil
+	}
+	return func() tea.Msg {
+		return savedMsg{name: name, err: m.store.Create(context.Background(), name, secret)}
+	}
+}
+
+func (m *model) viewForm() string {
+	f := m.form
+
+	label := func(row int, text string) string {
+		text = fmt.Sprintf("%-12s", text)
+		if row == f.focus {
+			return selectedStyle.Render(text)
+		}
+		return text
+	}
+
+	var lines []string
+	if f.edit != nil {
+		lines = append(lines, titleStyle.Render("Edit "+f.edit.Name), "")
+	} else {
+		lines = append(lines, titleStyle.Render("New secret"), "")
+		typeName := "no editable types"
+		if len(f.schemas) > 0 {
+			typeName = "‹ " + string(f.schemas[f.schema].Type) + " ›"
+		}
+		lines = append(lines,
+			label(0, "type:")+" "+typeName,
+			label(1, "name:")+" "+f.name.View(),
+		)
+	}
+
+	for i, field := range f.fields {
+		name := field.Name
+		if field.Required {
+			name += "*"
+		}
+		lines = append(lines, label(f.firstField()+i, name+":")+" "+f.inputs[i].View())
+	}
+
+	lines = append(lines, "")
+	if f.err != "" {
+		lines = append(lines, errorStyle.Render(f.err))
+	}
+	lines = append(lines, dimStyle.Render("tab/↑↓ move  ←→ type  ctrl+r show value  ctrl+s save  esc cancel"))
+
+	return lipgloss.NewStyle().Padding(1, 2).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,261 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajugalushkin/goph-keeper/client/internal/clipboard"
+	"github.com/ajugalushkin/goph-keeper/client/internal/vaulttypes"
+)
+
+// Entry расшифрованный секрет, показываемый в интерфейсе
+type Entry struct {
+	Name    string
+	Version string
+	Type    vaulttypes.VaultType
+	Folder  string
+	Tags    []string
+	// Values значения полей по именам полей схемы типа
+	Values map[string]string
+}
+
+// Store источник секретов для интерфейса
+type Store interface {
+	// List возвращает все секреты пользователя
+	List(ctx context.Context) ([]Entry, error)
+	// Create создает новый секрет
+	Create(ctx context.Context, name string, secret vaulttypes.Vault) error
+	// Update заменяет содержимое секрета, если он не менялся с версии entry.Version
+	Update(ctx context.Context, entry Entry, secret vaulttypes.Vault) error
+	// Unlock проверяет пароль учетной записи после блокировки
+	Unlock(ctx context.Context, password string) error
+	// Account возвращает адрес учетной записи
+	Account() string
+}
+
+// Options параметры интерфейса
+type Options struct {
+	// AutoLock время бездействия, после которого интерфейс блокируется.
+	// Ноль отключает блокировку.
+	AutoLock time.Duration
+	// Clipboard буфер обмена, nil если он недоступен
+	Clipboard clipboard.Clipboard
+	// ClipboardErr причина, по которой буфер обмена недоступен
+	ClipboardErr error
+}
+
+// Run запускает полноэкранный интерфейс и ждет его завершения
+func Run(store Store, opts Options) error {
+	_, err := tea.NewProgram(newModel(store, opts), tea.WithAltScreen()).Run()
+	return err
+}
+
+type mode int
+
+const (
+	modeList mode = iota
+	modeDetail
+	modeForm
+	modeLocked
+)
+
+type (
+	loadedMsg struct {
+		entries []Entry
+		err     error
+	}
+	savedMsg struct {
+		name string
+		err  error
+	}
+	unlockedMsg struct {
+		err error
+	}
+	tickMsg time.Time
+)
+
+type model struct {
+	store Store
+	opts  Options
+
+	mode    mode
+	entries []Entry
+	// visible индексы секретов, прошедших поиск, в порядке показа
+	visible []int
+	cursor  int
+
+	search    textinput.Model
+	searching bool
+
+	field    int
+	revealed map[string]bool
+
+	form     *form
+	password textinput.Model
+
+	status       string
+	lastActivity time.Time
+	width        int
+	height       int
+}
+
+func newModel(store Store, opts Options) *model {
+	search := textinput.New()
+	search.Prompt = "/ "
+	search.Placeholder = "search"
+
+	password := textinput.New()
+	password.Prompt = "Password: "
+	password.EchoMode = textinput.EchoPassword
+
+	return &model{
+		store:        store,
+		opts:         opts,
+		search:       search,
+		password:     password,
+		revealed:     make(map[string]bool),
+		status:       "Loading...",
+		lastActivity: time.Now(),
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	return tea.Batch(m.load(), tick())
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (m *model) load() tea.Cmd {
+	return func() tea.Msg {
+		entries, err := m.store.List(context.Background())
+		return loadedMsg{entries: entries, err: err}
+	}
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case tickMsg:
+		if m.mode != modeLocked && m.opts.AutoLock > 0 && time.Since(m.lastActivity) >= m.opts.AutoLock {
+			m.lock("Locked after inactivity")
+		}
+		return m, tick()
+
+	case loadedMsg:
+		if m.mode == modeLocked {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.status = "Failed to load secrets: " + msg.err.Error()
+			return m, nil
+		}
+		m.entries = msg.entries
+		m.filter()
+		m.status = ""
+		return m, nil
+
+	case savedMsg:
+		if m.form == nil {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.form.err = msg.err.Error()
+			return m, nil
+		}
+		m.form = nil
+		m.mode = modeList
+		m.status = "Saved " + msg.name
+		return m, m.load()
+
+	case unlockedMsg:
+		if msg.err != nil {
+			m.password.Reset()
+			m.status = "Unlock failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.password.Reset()
+		m.password.Blur()
+		m.mode = modeList
+		m.status = "Loading..."
+		return m, m.load()
+
+	case tea.KeyMsg:
+		m.lastActivity = time.Now()
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeList:
+			return m.updateList(msg)
+		case modeDetail:
+			return m.updateDetail(msg)
+		case modeForm:
+			return m.updateForm(msg)
+		case modeLocked:
+			return m.updateLocked(msg)
+		}
+	}
+	return m, nil
+}
+
+// lock стирает расшифрованные секреты из памяти и требует пароль
+func (m *model) lock(status string) {
+	m.entries, m.visible, m.cursor = nil, nil, 0
+	m.form = nil
+	m.revealed = make(map[string]bool)
+	m.mode = modeLocked
+	m.password.Reset()
+	m.password.Focus()
+	m.status = status
+}
+
+func (m *model) updateLocked(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m, tea.Quit
+	case "enter":
+		password := m.password.Value()
+		m.status = "Unlocking..."
+		return m, func() tea.Msg {
+			return unlockedMsg{err: m.store.Unlock(context.Background(), password)}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.password, cmd = m.password.Update(msg)
+	return m, cmd
+}
+
+func (m *model) copyField(entry Entry, name string) {
+	if m.opts.Clipboard == nil {
+		m.status = "Clipboard unavailable"
+		if m.opts.ClipboardErr != nil {
+			m.status += ": " + m.opts.ClipboardErr.Error()
+		}
+		return
+	}
+	if err := m.opts.Clipboard.Copy(entry.Values[name]); err != nil {
+		m.status = "Failed to copy: " + err.Error()
+		return
+	}
+	m.status = "Copied " + name + " of " + entry.Name
+}
+
+func (m *model) View() string {
+	switch m.mode {
+	case modeLocked:
+		return m.viewLocked()
+	case modeForm:
+		return m.viewForm()
+	default:
+		return m.viewBrowse()
+	}
+}
@@ -8,6 +8,7 @@ type Card struct {
 	ExpiryDate   string
 	SecurityCode string
 	Holder       string
+	Notes        string `json:",omitempty"`
 }
 
 // Type возвращает тип хранимой информации
@@ -17,6 +18,10 @@ func (c Card) Type() VaultType {
 
 // String функция отображения приватной информации
 func (c Card) String() string {
-	return fmt.Sprintf("Number: %s, ExpiryDate: %s, SecurityCode: %s, Holder: %s",
+	s := fmt.Sprintf("Number: %s, ExpiryDate: %s, SecurityCode: %s, Holder: %s",
 		c.Number, c.ExpiryDate, c.SecurityCode, c.Holder)
+	if c.Notes != "" {
+		s += fmt.Sprintf(", Notes: %s", c.Notes)
+	}
+	return s
 }
@@ -6,6 +6,8 @@ import "fmt"
 type Credentials struct {
 	Login    string
 	Password string
+	URL      string `json:",omitempty"`
+	Notes    string `json:",omitempty"`
 }
 
 // Type возвращает тип хранимой информации
@@ -15,5 +17,12 @@ func (c Credentials) Type() VaultType {
 
 // String функция отображения приватной информации
 func (c Credentials) String() string {
-	return fmt.Sprintf("Login: %s, Password: %s", c.Login, c.Password)
+	s := fmt.Sprintf("Login: %s, Password: %s", c.Login, c.Password)
+	if c.URL != "" {
+		s += fmt.Sprintf(", URL: %s", c.URL)
+	}
+	if c.Notes != "" {
+		s += fmt.Sprintf(", Notes: %s", c.Notes)
+	}
+	return s
 }
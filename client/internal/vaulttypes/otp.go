@@ -0,0 +1,45 @@
+package vaulttypes
+
+import "fmt"
+
+const vaultTypeOTP VaultType = "otp"
+
+// OTP секрет двухфакторной аутентификации (TOTP или HOTP)
+type OTP struct {
+	Kind      string
+	Issuer    string `json:",omitempty"`
+	Account   string `json:",omitempty"`
+	Secret    string
+	Algorithm string
+	Digits    int
+	Period    int    `json:",omitempty"`
+	Counter   uint64 `json:",omitempty"`
+}
+
+// Type возвращает тип хранимой информации
+func (o OTP) Type() VaultType {
+	return vaultTypeOTP
+}
+
+// String функция отображения приватной информации
+func (o OTP) String() string {
+	return fmt.Sprintf("Kind: %s, Issuer: %s, Account: %s, Secret: %s, Algorithm: %s, Digits: %d, Period: %d, Counter: %d",
+		o.Kind, o.Issuer, o.Account, o.Secret, o.Algorithm, o.Digits, o.Period, o.Counter)
+}
+
+func init() {
+	MustRegister(Schema{
+		Type: vaultTypeOTP,
+		Fields: []Field{
+			{Name: "kind", Key: "Kind", Required: true, Usage: "OTP kind: totp or hotp"},
+			{Name: "issuer", Key: "Issuer", Usage: "Issuer"},
+			{Name: "account", Key: "Account", Usage: "Account name"},
+			{Name: "secret", Key: "Secret", Sensitive: true, Required: true, Usage: "Base32 shared secret"},
+			{Name: "algorithm", Key: "Algorithm", Usage: "Hash algorithm: SHA1, SHA256 or SHA512"},
+			{Name: "digits", Key: "Digits", Number: true, Usage: "Number of code digits"},
+			{Name: "period", Key: "Period", Number: true, Usage: "TOTP period in seconds"},
+			{Name: "counter", Key: "Counter", Number: true, Usage: "HOTP counter"},
+		},
+		Decode: decodeAs[OTP],
+	})
+}
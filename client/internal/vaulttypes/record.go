@@ -0,0 +1,79 @@
+package vaulttypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Record секрет пользовательского типа, описанного шаблоном в конфигурации
+type Record struct {
+	Kind   VaultType
+	Fields map[string]string
+}
+
+// Type возвращает тип хранимой информации
+func (r Record) Type() VaultType {
+	return r.Kind
+}
+
+// String функция отображения приватной информации
+func (r Record) String() string {
+	schema, ok := Lookup(r.Kind)
+	if !ok {
+		return fmt.Sprintf("%v", r.Fields)
+	}
+
+	parts := make([]string, 0, len(schema.Fields))
+	for _, field := range schema.Fields {
+		if value, ok := r.Fields[field.Name]; ok {
+			parts = append(parts, fmt.Sprintf("%s: %s", field.Name, value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// MarshalJSON сохраняет запись как плоский объект полей
+func (r Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.Fields)
+}
+
+// TemplateField поле пользовательского шаблона
+type TemplateField struct {
+	Name        string
+	Sensitive   bool
+	Required    bool
+	Description string
+}
+
+// RegisterTemplate регистрирует пользовательский тип секрета
+func RegisterTemplate(name string, fields []TemplateField) error {
+	vaultType := VaultType(name)
+
+	schemaFields := make([]Field, 0, len(fields))
+	for _, field := range fields {
+		usage := field.Description
+		if usage == "" {
+			usage = field.Name
+		}
+		schemaFields = append(schemaFields, Field{
+			Name:      field.Name,
+			Key:       field.Name,
+			Sensitive: field.Sensitive,
+			Required:  field.Required,
+			Usage:     usage,
+		})
+	}
+
+	return Register(Schema{
+		Type:   vaultType,
+		Fields: schemaFields,
+		Decode: func(data []byte) (Vault, error) {
+			record := Record{Kind: vaultType}
+			if err := json.Unmarshal(data, &record.Fields); err != nil {
+				return nil, err
+			}
+			return record, nil
+		},
+	})
+}
@@ -0,0 +1,202 @@
+package vaulttypes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Field описание поля типа секрета
+type Field struct {
+	// Name имя поля в командах клиента
+	Name string
+	// Key имя поля в сериализованном секрете
+	Key string
+	// Sensitive поле содержит секретное значение и не отображается без необходимости
+	Sensitive bool
+	// Required поле обязательно для заполнения
+	Required bool
+	// Binary значение поля двоичное и передается в base64
+	Binary bool
+	// Number значение поля числовое
+	Number bool
+	// Usage описание поля для справки
+	Usage string
+}
+
+// Schema описание типа секрета
+type Schema struct {
+	Type   VaultType
+	Fields []Field
+	// Decode восстанавливает секрет из сериализованных данных
+	Decode func(data []byte) (Vault, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[VaultType]Schema)
+)
+
+// Register добавляет тип секрета в реестр
+func Register(schema Schema) error {
+	if schema.Type == "" {
+		return fmt.Errorf("secret type name is empty")
+	}
+	if schema.Decode == nil {
+		return fmt.Errorf("secret type %s has no decoder", schema.Type)
+	}
+
+	names := make(map[string]bool, len(schema.Fields))
+	for _, field := range schema.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("secret type %s has a field without name", schema.Type)
+		}
+		if names[field.Name] {
+			return fmt.Errorf("secret type %s has duplicate field %s", schema.Type, field.Name)
+		}
+		names[field.Name] = true
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[schema.Type]; ok {
+		return fmt.Errorf("secret type %s is already registered", schema.Type)
+	}
+	registry[schema.Type] = schema
+	return nil
+}
+
+// MustRegister добавляет тип секрета в реестр, в случае ошибки паникуем
+func MustRegister(schema Schema) {
+	if err := Register(schema); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup возвращает описание типа секрета
+func Lookup(vaultType VaultType) (Schema, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	schema, ok := registry[vaultType]
+	return schema, ok
+}
+
+// Schemas возвращает все зарегистрированные типы, отсортированные по имени
+func Schemas() []Schema {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	schemas := make([]Schema, 0, len(registry))
+	for _, schema := range registry {
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Type < schemas[j].Type
+	})
+	return schemas
+}
+
+// Field возвращает описание поля по имени
+func (s Schema) Field(name string) (Field, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return Field{}, false
+}
+
+// Values возвращает значения полей секрета по именам полей схемы.
+// Двоичные поля возвращаются в base64.
+func Values(vault Vault) (map[string]string, error) {
+	schema, ok := Lookup(vault.Type())
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %s", vault.Type())
+	}
+
+	data, err := json.Marshal(vault)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(schema.Fields))
+	for _, field := range schema.Fields {
+		value, ok := raw[field.Key]
+		if !ok {
+			continue
+		}
+		if field.Number {
+			values[field.Name] = string(value)
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		values[field.Name] = s
+	}
+	return values, nil
+}
+
+// FromValues собирает секрет указанного типа из значений полей.
+// Двоичные поля ожидаются в base64.
+func FromValues(vaultType VaultType, values map[string]string) (Vault, error) {
+	schema, ok := Lookup(vaultType)
+	if !ok {
+		return nil, fmt.Errorf("unknown secret type %s", vaultType)
+	}
+
+	raw := make(map[string]any, len(schema.Fields))
+	for _, field := range schema.Fields {
+		value := values[field.Name]
+		if value == "" {
+			if field.Required {
+				return nil, fmt.Errorf("field %s is required", field.Name)
+			}
+			continue
+		}
+		if field.Binary {
+			if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+		}
+		if field.Number {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			raw[field.Key] = json.Number(value)
+			continue
+		}
+		raw[field.Key] = value
+	}
+	for name := range values {
+		if _, ok := schema.Field(name); !ok {
+			return nil, fmt.Errorf("secret type %s has no field %s", vaultType, name)
+		}
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Decode(data)
+}
+
+// decodeAs декодирует секрет встроенного типа
+func decodeAs[T Vault](data []byte) (Vault, error) {
+	var vault T
+	if err := json.Unmarshal(data, &vault); err != nil {
+		return nil, err
+	}
+	return vault, nil
+}
@@ -0,0 +1,36 @@
+package vaulttypes
+
+import "fmt"
+
+const vaultTypeSSHKey VaultType = "ssh"
+
+// SSHKey ключ SSH: закрытый ключ в формате OpenSSH и открытый ключ в формате authorized_keys
+type SSHKey struct {
+	PrivateKey string
+	PublicKey  string
+	Comment    string `json:",omitempty"`
+	Passphrase string `json:",omitempty"`
+}
+
+// Type возвращает тип хранимой информации
+func (k SSHKey) Type() VaultType {
+	return vaultTypeSSHKey
+}
+
+// String функция отображения приватной информации
+func (k SSHKey) String() string {
+	return fmt.Sprintf("PublicKey: %s, Comment: %s, PrivateKey:\n%s", k.PublicKey, k.Comment, k.PrivateKey)
+}
+
+func init() {
+	MustRegister(Schema{
+		Type: vaultTypeSSHKey,
+		Fields: []Field{
+			{Name: "private-key", Key: "PrivateKey", Sensitive: true, Required: true, Usage: "Private key in OpenSSH format"},
+			{Name: "public-key", Key: "PublicKey", Required: true, Usage: "Public key in authorized_keys format"},
+			{Name: "comment", Key: "Comment", Usage: "Key comment"},
+			{Name: "passphrase", Key: "Passphrase", Sensitive: true, Usage: "Private key passphrase"},
+		},
+		Decode: decodeAs[SSHKey],
+	})
+}
@@ -41,32 +41,47 @@ func DecodeVault(data []byte) (Vault, error) {
 		return nil, err
 	}
 
-	switch c.Type {
-	case vaultTypeCredentials:
-		var credentials Credentials
-		if err := json.Unmarshal(c.Data, &credentials); err != nil {
-			return nil, err
-		}
-		return credentials, nil
-	case vaultTypeText:
-		var text Text
-		if err := json.Unmarshal(c.Data, &text); err != nil {
-			return nil, err
-		}
-		return text, nil
-	case vaultTypeBin:
-		var bin Bin
-		if err := json.Unmarshal(c.Data, &bin); err != nil {
-			return nil, err
-		}
-		return bin, nil
-	case vaultTypeCard:
-		var card Card
-		if err := json.Unmarshal(c.Data, &card); err != nil {
-			return nil, err
-		}
-		return card, nil
-	default:
+	schema, ok := Lookup(c.Type)
+	if !ok {
 		return nil, errors.New("unknown secret type")
 	}
+	return schema.Decode(c.Data)
+}
+
+func init() {
+	MustRegister(Schema{
+		Type: vaultTypeCredentials,
+		Fields: []Field{
+			{Name: "login", Key: "Login", Required: true, Usage: "Login"},
+			{Name: "password", Key: "Password", Sensitive: true, Required: true, Usage: "Password"},
+			{Name: "url", Key: "URL", Usage: "Site URL"},
+			{Name: "notes", Key: "Notes", Usage: "Notes"},
+		},
+		Decode: decodeAs[Credentials],
+	})
+	MustRegister(Schema{
+		Type: vaultTypeText,
+		Fields: []Field{
+			{Name: "data", Key: "Data", Required: true, Usage: "Text data"},
+		},
+		Decode: decodeAs[Text],
+	})
+	MustRegister(Schema{
+		Type: vaultTypeBin,
+		Fields: []Field{
+			{Name: "data", Key: "Data", Sensitive: true, Required: true, Binary: true, Usage: "Binary data"},
+		},
+		Decode: decodeAs[Bin],
+	})
+	MustRegister(Schema{
+		Type: vaultTypeCard,
+		Fields: []Field{
+			{Name: "number", Key: "Number", Sensitive: true, Required: true, Usage: "Card number"},
+			{Name: "date", Key: "ExpiryDate", Sensitive: true, Required: true, Usage: "Card expiry date"},
+			{Name: "code", Key: "SecurityCode", Sensitive: true, Required: true, Usage: "Card security code"},
+			{Name: "holder", Key: "Holder", Required: true, Usage: "Card holder"},
+			{Name: "notes", Key: "Notes", Usage: "Card notes"},
+		},
+		Decode: decodeAs[Card],
+	})
 }
@@ -1,9 +1,20 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/ajugalushkin/goph-keeper/client/cmd"
+	"github.com/ajugalushkin/goph-keeper/client/internal/dockercred"
 )
 
 func main() {
+	// Docker вызывает помощника по имени docker-credential-<credsStore>
+	if strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") == dockercred.Binary {
+		cmd.ExecuteDockerCredential(os.Args[1:])
+		return
+	}
+
 	cmd.Execute()
 }
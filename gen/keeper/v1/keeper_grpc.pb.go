@@ -23,6 +23,12 @@ const (
 	KeeperServiceV1_CreateItemStreamV1_FullMethodName = "/keeper.v1.KeeperServiceV1/CreateItemStreamV1"
 	KeeperServiceV1_GetItemV1_FullMethodName          = "/keeper.v1.KeeperServiceV1/GetItemV1"
 	KeeperServiceV1_ListItemsV1_FullMethodName        = "/keeper.v1.KeeperServiceV1/ListItemsV1"
+	KeeperServiceV1_UpdateItemV1_FullMethodName       = "/keeper.v1.KeeperServiceV1/UpdateItemV1"
+	KeeperServiceV1_BatchCreateItemsV1_FullMethodName = "/keeper.v1.KeeperServiceV1/BatchCreateItemsV1"
+	KeeperServiceV1_BatchUpdateItemsV1_FullMethodName = "/keeper.v1.KeeperServiceV1/BatchUpdateItemsV1"
+	KeeperServiceV1_BatchGetItemsV1_FullMethodName    = "/keeper.v1.KeeperServiceV1/BatchGetItemsV1"
+	KeeperServiceV1_RenameItemV1_FullMethodName       = "/keeper.v1.KeeperServiceV1/RenameItemV1"
+	KeeperServiceV1_DeleteItemV1_FullMethodName       = "/keeper.v1.KeeperServiceV1/DeleteItemV1"
 )
 
 // KeeperServiceV1Client is the client API for KeeperServiceV1 service.
@@ -33,6 +39,12 @@ type KeeperServiceV1Client interface {
 	CreateItemStreamV1(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[CreateItemStreamRequestV1, CreateItemStreamResponseV1], error)
 	GetItemV1(ctx context.Context, in *GetItemRequestV1, opts ...grpc.CallOption) (*GetItemResponseV1, error)
 	ListItemsV1(ctx context.Context, in *ListItemsRequestV1, opts ...grpc.CallOption) (*ListItemsResponseV1, error)
+	UpdateItemV1(ctx context.Context, in *UpdateItemRequestV1, opts ...grpc.CallOption) (*UpdateItemResponseV1, error)
+	BatchCreateItemsV1(ctx context.Context, in *BatchCreateItemsRequestV1, opts ...grpc.CallOption) (*BatchItemsResponseV1, error)
+	BatchUpdateItemsV1(ctx context.Context, in *BatchUpdateItemsRequestV1, opts ...grpc.CallOption) (*BatchItemsResponseV1, error)
+	BatchGetItemsV1(ctx context.Context, in *BatchGetItemsRequestV1, opts ...grpc.CallOption) (*BatchGetItemsResponseV1, error)
+	RenameItemV1(ctx context.Context, in *RenameItemRequestV1, opts ...grpc.CallOption) (*RenameItemResponseV1, error)
+	DeleteItemV1(ctx context.Context, in *DeleteItemRequestV1, opts ...grpc.CallOption) (*DeleteItemResponseV1, error)
 }
 
 type keeperServiceV1Client struct {
@@ -86,6 +98,66 @@ func (c *keeperServiceV1Client) ListItemsV1(ctx context.Context, in *ListItemsRe
 	return out, nil
 }
 
+func (c *keeperServiceV1Client) UpdateItemV1(ctx context.Context, in *UpdateItemRequestV1, opts ...grpc.CallOption) (*UpdateItemResponseV1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateItemResponseV1)
+	err := c.cc.Invoke(ctx, KeeperServiceV1_UpdateItemV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceV1Client) BatchCreateItemsV1(ctx context.Context, in *BatchCreateItemsRequestV1, opts ...grpc.CallOption) (*BatchItemsResponseV1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchItemsResponseV1)
+	err := c.cc.Invoke(ctx, KeeperServiceV1_BatchCreateItemsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceV1Client) BatchUpdateItemsV1(ctx context.Context, in *BatchUpdateItemsRequestV1, opts ...grpc.CallOption) (*BatchItemsResponseV1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchItemsResponseV1)
+	err := c.cc.Invoke(ctx, KeeperServiceV1_BatchUpdateItemsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceV1Client) BatchGetItemsV1(ctx context.Context, in *BatchGetItemsRequestV1, opts ...grpc.CallOption) (*BatchGetItemsResponseV1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetItemsResponseV1)
+	err := c.cc.Invoke(ctx, KeeperServiceV1_BatchGetItemsV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceV1Client) RenameItemV1(ctx context.Context, in *RenameItemRequestV1, opts ...grpc.CallOption) (*RenameItemResponseV1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenameItemResponseV1)
+	err := c.cc.Invoke(ctx, KeeperServiceV1_RenameItemV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keeperServiceV1Client) DeleteItemV1(ctx context.Context, in *DeleteItemRequestV1, opts ...grpc.CallOption) (*DeleteItemResponseV1, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteItemResponseV1)
+	err := c.cc.Invoke(ctx, KeeperServiceV1_DeleteItemV1_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KeeperServiceV1Server is the server API for KeeperServiceV1 service.
 // All implementations must embed UnimplementedKeeperServiceV1Server
 // for forward compatibility.
@@ -94,6 +166,12 @@ type KeeperServiceV1Server interface {
 	CreateItemStreamV1(grpc.ClientStreamingServer[CreateItemStreamRequestV1, CreateItemStreamResponseV1]) error
 	GetItemV1(context.Context, *GetItemRequestV1) (*GetItemResponseV1, error)
 	ListItemsV1(context.Context, *ListItemsRequestV1) (*ListItemsResponseV1, error)
+	UpdateItemV1(context.Context, *UpdateItemRequestV1) (*UpdateItemResponseV1, error)
+	BatchCreateItemsV1(context.Context, *BatchCreateItemsRequestV1) (*BatchItemsResponseV1, error)
+	BatchUpdateItemsV1(context.Context, *BatchUpdateItemsRequestV1) (*BatchItemsResponseV1, error)
+	BatchGetItemsV1(context.Context, *BatchGetItemsRequestV1) (*BatchGetItemsResponseV1, error)
+	RenameItemV1(context.Context, *RenameItemRequestV1) (*RenameItemResponseV1, error)
+	DeleteItemV1(context.Context, *DeleteItemRequestV1) (*DeleteItemResponseV1, error)
 	mustEmbedUnimplementedKeeperServiceV1Server()
 }
 
@@ -116,6 +194,24 @@ func (UnimplementedKeeperServiceV1Server) GetItemV1(context.Context, *GetItemReq
 func (UnimplementedKeeperServiceV1Server) ListItemsV1(context.Context, *ListItemsRequestV1) (*ListItemsResponseV1, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListItemsV1 not implemented")
 }
+func (UnimplementedKeeperServiceV1Server) UpdateItemV1(context.Context, *UpdateItemRequestV1) (*UpdateItemResponseV1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateItemV1 not implemented")
+}
+func (UnimplementedKeeperServiceV1Server) BatchCreateItemsV1(context.Context, *BatchCreateItemsRequestV1) (*BatchItemsResponseV1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateItemsV1 not implemented")
+}
+func (UnimplementedKeeperServiceV1Server) BatchUpdateItemsV1(context.Context, *BatchUpdateItemsRequestV1) (*BatchItemsResponseV1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateItemsV1 not implemented")
+}
+func (UnimplementedKeeperServiceV1Server) BatchGetItemsV1(context.Context, *BatchGetItemsRequestV1) (*BatchGetItemsResponseV1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetItemsV1 not implemented")
+}
+func (UnimplementedKeeperServiceV1Server) RenameItemV1(context.Context, *RenameItemRequestV1) (*RenameItemResponseV1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameItemV1 not implemented")
+}
+func (UnimplementedKeeperServiceV1Server) DeleteItemV1(context.Context, *DeleteItemRequestV1) (*DeleteItemResponseV1, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteItemV1 not implemented")
+}
 func (UnimplementedKeeperServiceV1Server) mustEmbedUnimplementedKeeperServiceV1Server() {}
 func (UnimplementedKeeperServiceV1Server) testEmbeddedByValue()                         {}
 
@@ -198,6 +294,114 @@ func _KeeperServiceV1_ListItemsV1_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KeeperServiceV1_UpdateItemV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateItemRequestV1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceV1Server).UpdateItemV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperServiceV1_UpdateItemV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceV1Server).UpdateItemV1(ctx, req.(*UpdateItemRequestV1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperServiceV1_BatchCreateItemsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateItemsRequestV1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceV1Server).BatchCreateItemsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperServiceV1_BatchCreateItemsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceV1Server).BatchCreateItemsV1(ctx, req.(*BatchCreateItemsRequestV1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperServiceV1_BatchUpdateItemsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateItemsRequestV1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceV1Server).BatchUpdateItemsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperServiceV1_BatchUpdateItemsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceV1Server).BatchUpdateItemsV1(ctx, req.(*BatchUpdateItemsRequestV1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperServiceV1_BatchGetItemsV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetItemsRequestV1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceV1Server).BatchGetItemsV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperServiceV1_BatchGetItemsV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceV1Server).BatchGetItemsV1(ctx, req.(*BatchGetItemsRequestV1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperServiceV1_RenameItemV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameItemRequestV1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceV1Server).RenameItemV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperServiceV1_RenameItemV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceV1Server).RenameItemV1(ctx, req.(*RenameItemRequestV1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeeperServiceV1_DeleteItemV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteItemRequestV1)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeeperServiceV1Server).DeleteItemV1(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeeperServiceV1_DeleteItemV1_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeeperServiceV1Server).DeleteItemV1(ctx, req.(*DeleteItemRequestV1))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KeeperServiceV1_ServiceDesc is the grpc.ServiceDesc for KeeperServiceV1 service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -217,6 +421,30 @@ var KeeperServiceV1_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListItemsV1",
 			Handler:    _KeeperServiceV1_ListItemsV1_Handler,
 		},
+		{
+			MethodName: "UpdateItemV1",
+			Handler:    _KeeperServiceV1_UpdateItemV1_Handler,
+		},
+		{
+			MethodName: "BatchCreateItemsV1",
+			Handler:    _KeeperServiceV1_BatchCreateItemsV1_Handler,
+		},
+		{
+			MethodName: "BatchUpdateItemsV1",
+			Handler:    _KeeperServiceV1_BatchUpdateItemsV1_Handler,
+		},
+		{
+			MethodName: "BatchGetItemsV1",
+			Handler:    _KeeperServiceV1_BatchGetItemsV1_Handler,
+		},
+		{
+			MethodName: "RenameItemV1",
+			Handler:    _KeeperServiceV1_RenameItemV1_Handler,
+		},
+		{
+			MethodName: "DeleteItemV1",
+			Handler:    _KeeperServiceV1_DeleteItemV1_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -28,6 +28,9 @@ func main() {
 
 	application := app.New(log, cfg)
 	go application.GRPCSrv.MustRun()
+	if application.HTTPSrv != nil {
+		go application.HTTPSrv.MustRun()
+	}
 
 	// Graceful shutdown
 	stop := make(chan os.Signal, 1)
@@ -36,6 +39,9 @@ func main() {
 	sign := <-stop
 	log.Info("stopping application", slog.String("signal", sign.String()))
 
+	if application.HTTPSrv != nil {
+		application.HTTPSrv.Stop()
+	}
 	application.GRPCSrv.Stop()
 	log.Info("application stopped")
 }
@@ -24,12 +24,21 @@ type Token struct {
 	Secret string        `yaml:"secret"`
 }
 
+// Health параметры проверок состояния. Пустой Address отключает
+// HTTP сервер /healthz и /readyz, статус grpc.health.v1 доступен всегда.
+type Health struct {
+	Address  string        `yaml:"address"`
+	Interval time.Duration `yaml:"interval" env-default:"10s"`
+	Timeout  time.Duration `yaml:"timeout" env-default:"2s"`
+}
+
 // Config структура параметров заауска.
 type Config struct {
 	Env     string `yaml:"env" env-required:"true"`
 	Storage Storage
 	GRPC    GRPC
 	Token   Token
+	Health  Health
 }
 
 // MustLoad функция заполнения структуры Config, в случае ошибки паникуем.
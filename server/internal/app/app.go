@@ -5,12 +5,15 @@ import (
 
 	"github.com/ajugalushkin/goph-keeper/server/config"
 	grpcapp "github.com/ajugalushkin/goph-keeper/server/internal/app/grpc"
+	httpapp "github.com/ajugalushkin/goph-keeper/server/internal/app/http"
 	"github.com/ajugalushkin/goph-keeper/server/internal/services"
 	"github.com/ajugalushkin/goph-keeper/server/internal/storage/postgres"
 )
 
 type App struct {
 	GRPCSrv *grpcapp.App
+	// HTTPSrv сервер /healthz и /readyz, nil если не настроен
+	HTTPSrv *httpapp.App
 }
 
 func New(
@@ -32,16 +35,28 @@ func New(
 
 	serviceAuth := services.NewAuthService(log, userStorage, userStorage, jwtManager)
 	serviceKeeper := services.NewKeeperService(log, vaultStorage, vaultStorage)
+	serviceHealth := services.NewHealthService(log, cfg.Health.Timeout, map[string]services.Pinger{
+		"users":  userStorage,
+		"vaults": vaultStorage,
+	})
 
 	grpcApp := grpcapp.New(
 		log,
 		serviceAuth,
 		serviceKeeper,
 		jwtManager,
+		serviceHealth,
+		cfg.Health.Interval,
 		cfg.GRPC.Address,
 	)
 
+	var httpApp *httpapp.App
+	if cfg.Health.Address != "" {
+		httpApp = httpapp.New(log, serviceHealth, cfg.Health.Address)
+	}
+
 	return &App{
 		GRPCSrv: grpcApp,
+		HTTPSrv: httpApp,
 	}
 }
@@ -1,23 +1,35 @@
 package grpcapp
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	authv1 "github.com/ajugalushkin/goph-keeper/gen/auth/v1"
+	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 	authhandlerv1 "github.com/ajugalushkin/goph-keeper/server/internal/handlers/grpc/auth/v1"
 	keeperhandlerv1 "github.com/ajugalushkin/goph-keeper/server/internal/handlers/grpc/keeper/v1"
 	"github.com/ajugalushkin/goph-keeper/server/internal/services"
 )
 
+// defaultHealthInterval период проверки готовности по умолчанию
+const defaultHealthInterval = 10 * time.Second
+
 type App struct {
-	log        *slog.Logger
-	gRPCServer *grpc.Server
-	address    string
+	log            *slog.Logger
+	gRPCServer     *grpc.Server
+	healthServer   *health.Server
+	healthService  *services.Health
+	healthInterval time.Duration
+	stop           chan struct{}
+	address        string
 }
 
 // New функция создания экземпляра приложения
@@ -26,6 +38,8 @@ func New(
 	authService authhandlerv1.Auth,
 	keeperService keeperhandlerv1.Keeper,
 	jwtManager *services.JWTManager,
+	healthService *services.Health,
+	healthInterval time.Duration,
 	Address string,
 ) *App {
 
@@ -37,13 +51,29 @@ func New(
 	keeperhandlerv1.Register(gRPCServer, keeperService)
 	authhandlerv1.Register(gRPCServer, authService)
 
+	// Статус grpc.health.v1 обновляется по результатам проверки готовности,
+	// до первой проверки сервер не готов
+	healthServer := health.NewServer()
+	for _, service := range servingServices() {
+		healthServer.SetServingStatus(service, healthv1.HealthCheckResponse_NOT_SERVING)
+	}
+	healthv1.RegisterHealthServer(gRPCServer, healthServer)
+
 	// Register reflection service on gRPC server.
 	reflection.Register(gRPCServer)
 
+	if healthInterval <= 0 {
+		healthInterval = defaultHealthInterval
+	}
+
 	return &App{
-		log:        log,
-		gRPCServer: gRPCServer,
-		address:    Address,
+		log:            log,
+		gRPCServer:     gRPCServer,
+		healthServer:   healthServer,
+		healthService:  healthService,
+		healthInterval: healthInterval,
+		stop:           make(chan struct{}),
+		address:        Address,
 	}
 }
 
@@ -51,6 +81,20 @@ func accessibleMethods() []string {
 	return []string{
 		authv1.AuthServiceV1_RegisterV1_FullMethodName,
 		authv1.AuthServiceV1_LoginV1_FullMethodName,
+		healthv1.Health_Check_FullMethodName,
+		healthv1.Health_Watch_FullMethodName,
+		// В grpc v1.66 у health нет константы для List, метод появился позже
+		"/grpc.health.v1.Health/List",
+	}
+}
+
+// servingServices сервисы, статус которых публикуется в grpc.health.v1.
+// Пустое имя означает сервер в целом.
+func servingServices() []string {
+	return []string{
+		"",
+		authv1.AuthServiceV1_ServiceDesc.ServiceName,
+		keeperv1.KeeperServiceV1_ServiceDesc.ServiceName,
 	}
 }
 
@@ -74,6 +118,8 @@ func (a *App) Run() error {
 
 	log.Info("grpc server is running", slog.String("addr", listener.Addr().String()))
 
+	go a.watchHealth()
+
 	if err := a.gRPCServer.Serve(listener); err != nil {
 		return fmt.Errorf("%s %w", op, err)
 	}
@@ -84,5 +130,45 @@ func (a *App) Stop() {
 	const op = "grpcapp.Stop"
 	a.log.With(slog.String("op", op)).
 		Info("grpc server is stopping", slog.String("address", a.address))
+	close(a.stop)
+	a.healthServer.Shutdown()
 	a.gRPCServer.GracefulStop()
 }
+
+// watchHealth периодически проверяет готовность и обновляет статус
+// сервисов, пока сервер не остановлен
+func (a *App) watchHealth() {
+	const op = "grpcapp.watchHealth"
+	log := a.log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(a.healthInterval)
+	defer ticker.Stop()
+
+	serving := false
+	for {
+		err := a.healthService.Ready(context.Background())
+		status := healthv1.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthv1.HealthCheckResponse_NOT_SERVING
+		}
+
+		for _, service := range servingServices() {
+			a.healthServer.SetServingStatus(service, status)
+		}
+
+		if ready := err == nil; ready != serving {
+			serving = ready
+			if ready {
+				log.Info("server is ready")
+			} else {
+				log.Error("server is not ready", slog.String("error", err.Error()))
+			}
+		}
+
+		select {
+		case <-a.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
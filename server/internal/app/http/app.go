@@ -0,0 +1,94 @@
+package httpapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ajugalushkin/goph-keeper/server/internal/services"
+)
+
+// shutdownTimeout время на завершение активных проверок при остановке
+const shutdownTimeout = 5 * time.Second
+
+type App struct {
+	log        *slog.Logger
+	httpServer *http.Server
+	address    string
+}
+
+// New функция создания HTTP сервера проверок состояния.
+// /healthz отвечает, пока процесс жив, /readyz - когда доступны
+// зависимости сервера.
+func New(
+	log *slog.Logger,
+	healthService *services.Health,
+	Address string,
+) *App {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		// Подробности ошибок только в журнале, в них может быть адрес базы
+		if err := healthService.Ready(r.Context()); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintln(w, "not ready")
+			return
+		}
+		_, _ = fmt.Fprintln(w, "ok")
+	})
+
+	return &App{
+		log: log,
+		httpServer: &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		address: Address,
+	}
+}
+
+// MustRun метод для запуска приложения, при возникновении ошибки паникуем
+func (a *App) MustRun() {
+	if err := a.Run(); err != nil {
+		panic(err)
+	}
+}
+
+func (a *App) Run() error {
+	const op = "httpapp.Run"
+	log := a.log.With(
+		slog.String("op", op),
+		slog.String("address", a.address))
+
+	listener, err := net.Listen("tcp", a.address)
+	if err != nil {
+		return fmt.Errorf("%s %w", op, err)
+	}
+
+	log.Info("health server is running", slog.String("addr", listener.Addr().String()))
+
+	if err := a.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("%s %w", op, err)
+	}
+	return nil
+}
+
+func (a *App) Stop() {
+	const op = "httpapp.Stop"
+	log := a.log.With(slog.String("op", op))
+	log.Info("health server is stopping", slog.String("address", a.address))
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		log.Error("health server shutdown failed", slog.String("error", err.Error()))
+	}
+}
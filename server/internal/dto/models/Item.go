@@ -1,10 +1,23 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Item struct {
-	Name    string
-	Content []byte
-	Version uuid.UUID
-	OwnerID int64
+	ID        int64
+	ItemID    uuid.UUID
+	Name      string
+	Content   []byte
+	Version   uuid.UUID
+	OwnerID   int64
+	Type      string
+	Folder    string
+	Tags      []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ReplaceMetadata при обновлении заменяет тип, папку и теги секрета
+	ReplaceMetadata bool
 }
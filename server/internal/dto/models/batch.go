@@ -0,0 +1,17 @@
+package models
+
+// BatchMode режим пакетной операции
+type BatchMode int
+
+const (
+	// BatchAtomic все элементы сохраняются в одной транзакции, ошибка любого отменяет пакет
+	BatchAtomic BatchMode = iota
+	// BatchBestEffort элементы с ошибками пропускаются, остальные сохраняются
+	BatchBestEffort
+)
+
+// BatchResult результат обработки одного элемента пакета
+type BatchResult struct {
+	Item *Item
+	Err  error
+}
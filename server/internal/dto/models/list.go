@@ -0,0 +1,34 @@
+package models
+
+// SortField поле, по которому сортируется список секретов
+type SortField int
+
+const (
+	SortByName SortField = iota
+	SortByCreatedAt
+	SortByUpdatedAt
+)
+
+// ListFilter условия отбора секретов
+type ListFilter struct {
+	Type       string
+	Tag        string
+	Folder     string
+	NamePrefix string
+}
+
+// ListCursor позиция последнего элемента предыдущей страницы
+type ListCursor struct {
+	Value string `json:"v"`
+	ID    int64  `json:"id"`
+}
+
+// ListOptions параметры постраничной выборки секретов
+type ListOptions struct {
+	Filter         ListFilter
+	SortBy         SortField
+	Descending     bool
+	Limit          int
+	After          *ListCursor
+	IncludeContent bool
+}
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
 	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
@@ -26,15 +27,50 @@ type Keeper interface {
 		ctx context.Context,
 		item *models.Item,
 	) (*models.Item, error)
+	UpdateItem(
+		ctx context.Context,
+		item *models.Item,
+	) (*models.Item, error)
 	GetItem(
 		ctx context.Context,
 		name string,
 		userID int64,
 	) (*models.Item, error)
+	GetItemByID(
+		ctx context.Context,
+		itemID uuid.UUID,
+		userID int64,
+	) (*models.Item, error)
+	RenameItem(
+		ctx context.Context,
+		item *models.Item,
+		newName string,
+	) (*models.Item, error)
+	DeleteItem(
+		ctx context.Context,
+		item *models.Item,
+	) (*models.Item, error)
 	ListItems(
 		ctx context.Context,
 		userID int64,
-	) (list []*models.Item, err error)
+		opts models.ListOptions,
+		pageToken string,
+	) (list []*models.Item, nextPageToken string, err error)
+	BatchCreateItems(
+		ctx context.Context,
+		items []*models.Item,
+		mode models.BatchMode,
+	) ([]models.BatchResult, error)
+	BatchUpdateItems(
+		ctx context.Context,
+		items []*models.Item,
+		mode models.BatchMode,
+	) ([]models.BatchResult, error)
+	BatchGetItems(
+		ctx context.Context,
+		names []string,
+		userID int64,
+	) (items []*models.Item, notFound []string, err error)
 }
 
 type serverAPI struct {
@@ -157,6 +193,9 @@ func (s *serverAPI) CreateItemV1(
 		Content: req.GetContent(),
 		Version: uuid.UUID{},
 		OwnerID: userID,
+		Type:    req.GetType(),
+		Folder:  req.GetFolder(),
+		Tags:    req.GetTags(),
 	})
 	if err != nil {
 		if errors.Is(err, storage.ErrItemConflict) {
@@ -168,14 +207,79 @@ func (s *serverAPI) CreateItemV1(
 	return &keeperv1.CreateItemResponseV1{
 		Name:    req.GetName(),
 		Version: item.Version.String(),
+		ItemId:  item.ItemID.String(),
+	}, nil
+}
+
+func (s *serverAPI) UpdateItemV1(
+	ctx context.Context,
+	req *keeperv1.UpdateItemRequestV1,
+) (*keeperv1.UpdateItemResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	version, err := uuid.Parse(req.GetVersion())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid version")
+	}
+
+	item, err := s.keeper.UpdateItem(ctx, updateItem(req, version, userID))
+	if err != nil {
+		if errors.Is(err, storage.ErrItemNotFound) {
+			return nil, status.Error(codes.NotFound, "item not found")
+		}
+		if errors.Is(err, storage.ErrItemVersion) {
+			return nil, status.Error(codes.Aborted, "item was modified concurrently")
+		}
+		return nil, status.Error(codes.Internal, "failed to update item")
+	}
+
+	return &keeperv1.UpdateItemResponseV1{
+		Name:    item.Name,
+		Version: item.Version.String(),
 	}, nil
 }
 
+// updateItem собирает модель обновления, метаданные заменяются только если
+// клиент их передал
+func updateItem(req *keeperv1.UpdateItemRequestV1, version uuid.UUID, userID int64) *models.Item {
+	item := &models.Item{
+		Name:    req.GetName(),
+		Content: req.GetContent(),
+		Version: version,
+		OwnerID: userID,
+	}
+	if metadata := req.GetMetadata(); metadata != nil {
+		item.ReplaceMetadata = true
+		item.Type = metadata.GetType()
+		item.Folder = metadata.GetFolder()
+		item.Tags = metadata.GetTags()
+	}
+	return item
+}
+
 func (s *serverAPI) GetItemV1(
 	ctx context.Context,
 	request *keeperv1.GetItemRequestV1,
 ) (*keeperv1.GetItemResponseV1, error) {
-	if request.GetName() == "" {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if request.GetName() == "" && request.GetItemId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "secret name is empty")
 	}
 
@@ -184,9 +288,14 @@ func (s *serverAPI) GetItemV1(
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
-	item, err := s.keeper.GetItem(ctx, request.GetName(), userID)
+	var item *models.Item
+	if request.GetItemId() != "" {
+		item, err = s.keeper.GetItemByID(ctx, uuid.MustParse(request.GetItemId()), userID)
+	} else {
+		item, err = s.keeper.GetItem(ctx, request.GetName(), userID)
+	}
 	if err != nil {
-		if errors.Is(err, storage.ErrUserNotFound) {
+		if errors.Is(err, storage.ErrItemNotFound) {
 			return nil, status.Error(codes.NotFound, "item not found")
 		}
 		return nil, status.Error(codes.Internal, "failed to get item")
@@ -196,6 +305,101 @@ func (s *serverAPI) GetItemV1(
 		Name:    item.Name,
 		Content: item.Content,
 		Version: item.Version.String(),
+		ItemId:  item.ItemID.String(),
+	}, nil
+}
+
+func (s *serverAPI) RenameItemV1(
+	ctx context.Context,
+	req *keeperv1.RenameItemRequestV1,
+) (*keeperv1.RenameItemResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetName() == "" && req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "secret name is empty")
+	}
+
+	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	item := &models.Item{
+		Name:    req.GetName(),
+		OwnerID: userID,
+	}
+	if req.GetItemId() != "" {
+		item.ItemID = uuid.MustParse(req.GetItemId())
+	}
+
+	item, err = s.keeper.RenameItem(ctx, item, req.GetNewName())
+	if err != nil {
+		if errors.Is(err, storage.ErrItemNotFound) {
+			return nil, status.Error(codes.NotFound, "item not found")
+		}
+		if errors.Is(err, storage.ErrItemConflict) {
+			return nil, status.Error(codes.AlreadyExists, "item already exists")
+		}
+		return nil, status.Error(codes.Internal, "failed to rename item")
+	}
+
+	return &keeperv1.RenameItemResponseV1{
+		ItemId:  item.ItemID.String(),
+		Name:    item.Name,
+		Version: item.Version.String(),
+	}, nil
+}
+
+func (s *serverAPI) DeleteItemV1(
+	ctx context.Context,
+	req *keeperv1.DeleteItemRequestV1,
+) (*keeperv1.DeleteItemResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if req.GetName() == "" && req.GetItemId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "secret name is empty")
+	}
+
+	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	item := &models.Item{
+		Name:    req.GetName(),
+		OwnerID: userID,
+	}
+	if req.GetItemId() != "" {
+		item.ItemID = uuid.MustParse(req.GetItemId())
+	}
+	if req.GetVersion() != "" {
+		item.Version = uuid.MustParse(req.GetVersion())
+	}
+
+	item, err = s.keeper.DeleteItem(ctx, item)
+	if err != nil {
+		if errors.Is(err, storage.ErrItemNotFound) {
+			return nil, status.Error(codes.NotFound, "item not found")
+		}
+		if errors.Is(err, storage.ErrItemVersion) {
+			return nil, status.Error(codes.Aborted, "item was modified concurrently")
+		}
+		return nil, status.Error(codes.Internal, "failed to delete item")
+	}
+
+	return &keeperv1.DeleteItemResponseV1{
+		ItemId: item.ItemID.String(),
+		Name:   item.Name,
 	}, nil
 }
 
@@ -203,25 +407,216 @@ func (s *serverAPI) ListItemsV1(
 	ctx context.Context,
 	req *keeperv1.ListItemsRequestV1,
 ) (*keeperv1.ListItemsResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "empty user id")
 	}
 
-	secrets, err := s.keeper.ListItems(ctx, userID)
+	opts := models.ListOptions{
+		Filter: models.ListFilter{
+			Type:       req.GetFilter().GetType(),
+			Tag:        req.GetFilter().GetTag(),
+			Folder:     req.GetFilter().GetFolder(),
+			NamePrefix: req.GetFilter().GetNamePrefix(),
+		},
+		SortBy:         sortField(req.GetSortBy()),
+		Descending:     req.GetDescending(),
+		Limit:          int(req.GetPageSize()),
+		IncludeContent: req.GetIncludeContent(),
+	}
+
+	secrets, nextPageToken, err := s.keeper.ListItems(ctx, userID, opts, req.GetPageToken())
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidPageToken) {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
 		return nil, status.Error(codes.Internal, "failed to list secrets")
 	}
 
 	keeperSecrets := make([]*keeperv1.SecretInfo, 0, len(secrets))
 	for _, secret := range secrets {
 		keeperSecrets = append(keeperSecrets, &keeperv1.SecretInfo{
-			Name:    secret.Name,
-			Content: secret.Content,
-			Version: secret.Version.String(),
+			ItemId:    secret.ItemID.String(),
+			Name:      secret.Name,
+			Content:   secret.Content,
+			Version:   secret.Version.String(),
+			Type:      secret.Type,
+			Folder:    secret.Folder,
+			Tags:      secret.Tags,
+			CreatedAt: timestamppb.New(secret.CreatedAt),
+			UpdatedAt: timestamppb.New(secret.UpdatedAt),
 		})
 	}
 	return &keeperv1.ListItemsResponseV1{
-		Secrets: keeperSecrets,
+		Secrets:       keeperSecrets,
+		NextPageToken: nextPageToken,
 	}, nil
 }
+
+func (s *serverAPI) BatchCreateItemsV1(
+	ctx context.Context,
+	req *keeperv1.BatchCreateItemsRequestV1,
+) (*keeperv1.BatchItemsResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	items := make([]*models.Item, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, &models.Item{
+			Name:    item.GetName(),
+			Content: item.GetContent(),
+			OwnerID: userID,
+			Type:    item.GetType(),
+			Folder:  item.GetFolder(),
+			Tags:    item.GetTags(),
+		})
+	}
+
+	results, err := s.keeper.BatchCreateItems(ctx, items, batchMode(req.GetMode()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to create items")
+	}
+
+	return &keeperv1.BatchItemsResponseV1{Results: batchResults(results)}, nil
+}
+
+func (s *serverAPI) BatchUpdateItemsV1(
+	ctx context.Context,
+	req *keeperv1.BatchUpdateItemsRequestV1,
+) (*keeperv1.BatchItemsResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	items := make([]*models.Item, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		version, err := uuid.Parse(item.GetVersion())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid version")
+		}
+		items = append(items, updateItem(item, version, userID))
+	}
+
+	results, err := s.keeper.BatchUpdateItems(ctx, items, batchMode(req.GetMode()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to update items")
+	}
+
+	return &keeperv1.BatchItemsResponseV1{Results: batchResults(results)}, nil
+}
+
+func (s *serverAPI) BatchGetItemsV1(
+	ctx context.Context,
+	req *keeperv1.BatchGetItemsRequestV1,
+) (*keeperv1.BatchGetItemsResponseV1, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if err := validator.Validate(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userID, ok := ctx.Value(services.ContextKeyUserID).(int64)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "empty user id")
+	}
+
+	secrets, notFound, err := s.keeper.BatchGetItems(ctx, req.GetNames(), userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get items")
+	}
+
+	keeperSecrets := make([]*keeperv1.SecretInfo, 0, len(secrets))
+	for _, secret := range secrets {
+		keeperSecrets = append(keeperSecrets, &keeperv1.SecretInfo{
+			ItemId:    secret.ItemID.String(),
+			Name:      secret.Name,
+			Content:   secret.Content,
+			Version:   secret.Version.String(),
+			Type:      secret.Type,
+			Folder:    secret.Folder,
+			Tags:      secret.Tags,
+			CreatedAt: timestamppb.New(secret.CreatedAt),
+			UpdatedAt: timestamppb.New(secret.UpdatedAt),
+		})
+	}
+	return &keeperv1.BatchGetItemsResponseV1{
+		Secrets:  keeperSecrets,
+		NotFound: notFound,
+	}, nil
+}
+
+func batchMode(mode keeperv1.BatchMode) models.BatchMode {
+	if mode == keeperv1.BatchMode_BATCH_MODE_BEST_EFFORT {
+		return models.BatchBestEffort
+	}
+	return models.BatchAtomic
+}
+
+// batchResults переводит результаты пакетной операции в ответ с кодом ошибки
+// для каждого элемента
+func batchResults(results []models.BatchResult) []*keeperv1.BatchItemResultV1 {
+	resp := make([]*keeperv1.BatchItemResultV1, 0, len(results))
+	for _, result := range results {
+		item := &keeperv1.BatchItemResultV1{
+			Name: result.Item.Name,
+			Code: int32(codes.OK),
+		}
+
+		switch {
+		case result.Err == nil:
+			item.Version = result.Item.Version.String()
+		case errors.Is(result.Err, storage.ErrItemConflict):
+			item.Code, item.Error = int32(codes.AlreadyExists), "item already exists"
+		case errors.Is(result.Err, storage.ErrItemNotFound):
+			item.Code, item.Error = int32(codes.NotFound), "item not found"
+		case errors.Is(result.Err, storage.ErrItemVersion):
+			item.Code, item.Error = int32(codes.Aborted), "item was modified concurrently"
+		case errors.Is(result.Err, storage.ErrBatchAborted):
+			item.Code, item.Error = int32(codes.Aborted), "batch rolled back"
+		default:
+			item.Code, item.Error = int32(codes.Internal), "failed to save item"
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+func sortField(sortBy keeperv1.ListItemsRequestV1_SortBy) models.SortField {
+	switch sortBy {
+	case keeperv1.ListItemsRequestV1_SORT_BY_CREATED_AT:
+		return models.SortByCreatedAt
+	case keeperv1.ListItemsRequestV1_SORT_BY_UPDATED_AT:
+		return models.SortByUpdatedAt
+	default:
+		return models.SortByName
+	}
+}
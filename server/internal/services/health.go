@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Pinger проверяет доступность зависимости сервера
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type Health struct {
+	log     *slog.Logger
+	timeout time.Duration
+	checks  map[string]Pinger
+}
+
+// NewHealthService создает проверку готовности сервера. checks задает
+// зависимости по имени, каждая проверка ограничена timeout.
+func NewHealthService(
+	log *slog.Logger,
+	timeout time.Duration,
+	checks map[string]Pinger,
+) *Health {
+	return &Health{
+		log:     log,
+		timeout: timeout,
+		checks:  checks,
+	}
+}
+
+// Ready проверяет все зависимости и возвращает ошибки недоступных
+func (h *Health) Ready(ctx context.Context) error {
+	const op = "services.Health.Ready"
+	log := h.log.With(slog.String("op", op))
+
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, len(names))
+	done := make(chan struct{}, len(names))
+	for i, name := range names {
+		go func() {
+			defer func() { done <- struct{}{} }()
+
+			checkCtx := ctx
+			if h.timeout > 0 {
+				var cancel context.CancelFunc
+				checkCtx, cancel = context.WithTimeout(ctx, h.timeout)
+				defer cancel()
+			}
+
+			if err := h.checks[name].Ping(checkCtx); err != nil {
+				log.Warn("dependency is not ready",
+					slog.String("dependency", name),
+					slog.String("error", err.Error()))
+				errs[i] = fmt.Errorf("%s: %w", name, err)
+			}
+		}()
+	}
+	for range names {
+		<-done
+	}
+
+	return errors.Join(errs...)
+}
@@ -2,7 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
 )
@@ -15,13 +22,29 @@ type Keeper struct {
 
 type ItemProvider interface {
 	Get(ctx context.Context, name string, userID int64) (*models.Item, error)
-	List(ctx context.Context, userID int64) ([]*models.Item, error)
+	GetByID(ctx context.Context, itemID uuid.UUID, userID int64) (*models.Item, error)
+	List(ctx context.Context, userID int64, opts models.ListOptions) ([]*models.Item, error)
+	GetBatch(ctx context.Context, names []string, userID int64) ([]*models.Item, error)
 }
 
 type ItemSaver interface {
 	Create(ctx context.Context, item *models.Item) (*models.Item, error)
+	Update(ctx context.Context, item *models.Item) (*models.Item, error)
+	Rename(ctx context.Context, item *models.Item, newName string) (*models.Item, error)
+	Delete(ctx context.Context, item *models.Item) (*models.Item, error)
+	CreateBatch(ctx context.Context, items []*models.Item, mode models.BatchMode) ([]models.BatchResult, error)
+	UpdateBatch(ctx context.Context, items []*models.Item, mode models.BatchMode) ([]models.BatchResult, error)
 }
 
+const (
+	// DefaultPageSize размер страницы списка, если клиент его не указал
+	DefaultPageSize = 100
+	// MaxPageSize максимальный размер страницы списка
+	MaxPageSize = 1000
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
 func NewKeeperService(
 	log *slog.Logger,
 	provider ItemProvider,
@@ -48,6 +71,21 @@ func (k Keeper) CreateItem(ctx context.Context, item *models.Item) (*models.Item
 	return newItem, nil
 }
 
+// UpdateItem обновляет содержимое секрета, если клиент изменял последнюю версию
+func (k Keeper) UpdateItem(ctx context.Context, item *models.Item) (*models.Item, error) {
+	const op = "services.keeper.updateItem"
+	log := k.log.With("op", op)
+
+	updated, err := k.itmSaver.Update(ctx, item)
+	if err != nil {
+		log.Debug("Failed to update item", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	log.Debug("Successfully updated item")
+	return updated, nil
+}
+
 func (k Keeper) GetItem(ctx context.Context, name string, userID int64) (*models.Item, error) {
 	const op = "services.keeper.getItem"
 	k.log.With("op", op)
@@ -62,16 +100,208 @@ func (k Keeper) GetItem(ctx context.Context, name string, userID int64) (*models
 	return item, nil
 }
 
-func (k Keeper) ListItems(ctx context.Context, userID int64) (list []*models.Item, err error) {
+// GetItemByID возвращает секрет по его постоянному идентификатору
+func (k Keeper) GetItemByID(ctx context.Context, itemID uuid.UUID, userID int64) (*models.Item, error) {
+	const op = "services.keeper.getItemByID"
+	log := k.log.With("op", op)
+
+	item, err := k.itmProvider.GetByID(ctx, itemID, userID)
+	if err != nil {
+		log.Debug("Failed to get item", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	log.Debug("Successfully get item")
+	return item, nil
+}
+
+// RenameItem меняет имя секрета, не затрагивая его содержимое и версию
+func (k Keeper) RenameItem(ctx context.Context, item *models.Item, newName string) (*models.Item, error) {
+	const op = "services.keeper.renameItem"
+	log := k.log.With("op", op)
+
+	renamed, err := k.itmSaver.Rename(ctx, item, newName)
+	if err != nil {
+		log.Debug("Failed to rename item", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	log.Debug("Successfully renamed item")
+	return renamed, nil
+}
+
+// DeleteItem удаляет секрет
+func (k Keeper) DeleteItem(ctx context.Context, item *models.Item) (*models.Item, error) {
+	const op = "services.keeper.deleteItem"
+	log := k.log.With("op", op)
+
+	deleted, err := k.itmSaver.Delete(ctx, item)
+	if err != nil {
+		log.Debug("Failed to delete item", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	log.Debug("Successfully deleted item")
+	return deleted, nil
+}
+
+// BatchCreateItems создает несколько секретов в одной транзакции
+func (k Keeper) BatchCreateItems(
+	ctx context.Context,
+	items []*models.Item,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	const op = "services.keeper.batchCreateItems"
+	log := k.log.With("op", op)
+
+	results, err := k.itmSaver.CreateBatch(ctx, items, mode)
+	if err != nil {
+		log.Debug("Failed to create items", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	log.Debug("Successfully processed batch", slog.Int("count", len(results)))
+	return results, nil
+}
+
+// BatchUpdateItems обновляет несколько секретов в одной транзакции
+func (k Keeper) BatchUpdateItems(
+	ctx context.Context,
+	items []*models.Item,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	const op = "services.keeper.batchUpdateItems"
+	log := k.log.With("op", op)
+
+	results, err := k.itmSaver.UpdateBatch(ctx, items, mode)
+	if err != nil {
+		log.Debug("Failed to update items", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	log.Debug("Successfully processed batch", slog.Int("count", len(results)))
+	return results, nil
+}
+
+// BatchGetItems возвращает найденные секреты и имена отсутствующих
+func (k Keeper) BatchGetItems(
+	ctx context.Context,
+	names []string,
+	userID int64,
+) (items []*models.Item, notFound []string, err error) {
+	const op = "services.keeper.batchGetItems"
+	log := k.log.With("op", op)
+
+	items, err = k.itmProvider.GetBatch(ctx, names, userID)
+	if err != nil {
+		log.Debug("Failed to get items", slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	found := make(map[string]bool, len(items))
+	for _, item := range items {
+		found[item.Name] = true
+	}
+	for _, name := range names {
+		if !found[name] {
+			notFound = append(notFound, name)
+			found[name] = true
+		}
+	}
+
+	log.Debug("Successfully get items", slog.Int("count", len(items)))
+	return items, notFound, nil
+}
+
+// ListItems возвращает страницу секретов пользователя и токен следующей страницы.
+// Пустой токен означает, что страница последняя.
+func (k Keeper) ListItems(
+	ctx context.Context,
+	userID int64,
+	opts models.ListOptions,
+	pageToken string,
+) (list []*models.Item, nextPageToken string, err error) {
 	const op = "services.keeper.listItem"
-	k.log.With("op", op)
+	log := k.log.With("op", op)
+
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultPageSize
+	}
+	if opts.Limit > MaxPageSize {
+		opts.Limit = MaxPageSize
+	}
+	pageSize := opts.Limit
+
+	if pageToken != "" {
+		opts.After, err = decodePageToken(pageToken, opts)
+		if err != nil {
+			log.Debug("Failed to decode page token", slog.String("error", err.Error()))
+			return nil, "", fmt.Errorf("%s: %w", op, ErrInvalidPageToken)
+		}
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	opts.Limit = pageSize + 1
+	list, err = k.itmProvider.List(ctx, userID, opts)
+	if err != nil {
+		log.Debug("Failed to list items", slog.String("error", err.Error()))
+		return nil, "", err
+	}
+
+	if len(list) > pageSize {
+		list = list[:pageSize]
+		nextPageToken, err = encodePageToken(list[len(list)-1], opts)
+		if err != nil {
+			return nil, "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	log.Debug("Successfully get list", slog.Int("count", len(list)))
+	return list, nextPageToken, nil
+}
+
+// pageToken содержимое токена страницы. Параметры сортировки сохраняются
+// в токене, чтобы нельзя было продолжить выборку с другим порядком.
+type pageToken struct {
+	models.ListCursor
+	SortBy     models.SortField `json:"s"`
+	Descending bool             `json:"d"`
+}
 
-	list, err = k.itmProvider.List(ctx, userID)
+func encodePageToken(last *models.Item, opts models.ListOptions) (string, error) {
+	token := pageToken{
+		ListCursor: models.ListCursor{ID: last.ID},
+		SortBy:     opts.SortBy,
+		Descending: opts.Descending,
+	}
+	switch opts.SortBy {
+	case models.SortByCreatedAt:
+		token.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	case models.SortByUpdatedAt:
+		token.Value = last.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		token.Value = last.Name
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(s string, opts models.ListOptions) (*models.ListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
 	if err != nil {
-		k.log.Debug("Failed to list items", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	k.log.Debug("Successfully get list")
-	return list, nil
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	if token.SortBy != opts.SortBy || token.Descending != opts.Descending {
+		return nil, errors.New("sort order does not match page token")
+	}
+	return &token.ListCursor, nil
 }
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
+)
+
+const testUserID = 1
+
+// fakeProvider хранит секреты в памяти и выбирает страницы по ключу
+// так же, как postgres.VaultStorage.List
+type fakeProvider struct {
+	items    []*models.Item
+	nextID   int64
+	lastOpts models.ListOptions
+}
+
+func (p *fakeProvider) add(name string, createdAt time.Time) {
+	p.nextID++
+	p.items = append(p.items, &models.Item{
+		ID:        p.nextID,
+		ItemID:    uuid.New(),
+		Name:      name,
+		OwnerID:   testUserID,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	})
+}
+
+func (p *fakeProvider) Get(ctx context.Context, name string, userID int64) (*models.Item, error) {
+	panic("not implemented")
+}
+
+func (p *fakeProvider) GetByID(ctx context.Context, itemID uuid.UUID, userID int64) (*models.Item, error) {
+	panic("not implemented")
+}
+
+func (p *fakeProvider) GetBatch(ctx context.Context, names []string, userID int64) ([]*models.Item, error) {
+	panic("not implemented")
+}
+
+func (p *fakeProvider) List(ctx context.Context, userID int64, opts models.ListOptions) ([]*models.Item, error) {
+	p.lastOpts = opts
+
+	// compare сравнивает секреты по ключу (колонка сортировки, id)
+	compare := func(a *models.Item, value string, id int64) int {
+		var c int
+		switch opts.SortBy {
+		case models.SortByCreatedAt, models.SortByUpdatedAt:
+			at, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				panic(err)
+			}
+			field := a.CreatedAt
+			if opts.SortBy == models.SortByUpdatedAt {
+				field = a.UpdatedAt
+			}
+			c = field.Compare(at)
+		default:
+			c = strings.Compare(a.Name, value)
+		}
+		if c == 0 {
+			c = int(a.ID - id)
+		}
+		if opts.Descending {
+			c = -c
+		}
+		return c
+	}
+	key := func(a *models.Item) string {
+		switch opts.SortBy {
+		case models.SortByCreatedAt:
+			return a.CreatedAt.Format(time.RFC3339Nano)
+		case models.SortByUpdatedAt:
+			return a.UpdatedAt.Format(time.RFC3339Nano)
+		default:
+			return a.Name
+		}
+	}
+
+	list := make([]*models.Item, 0)
+	for _, item := range p.items {
+		if item.OwnerID != userID || !strings.HasPrefix(item.Name, opts.Filter.NamePrefix) {
+			continue
+		}
+		if opts.After != nil && compare(item, opts.After.Value, opts.After.ID) <= 0 {
+			continue
+		}
+		list = append(list, item)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return compare(list[i], key(list[j]), list[j].ID) < 0
+	})
+
+	if len(list) > opts.Limit {
+		list = list[:opts.Limit]
+	}
+	return list, nil
+}
+
+func newTestKeeper(provider *fakeProvider) *Keeper {
+	return NewKeeperService(slog.New(slog.NewTextHandler(io.Discard, nil)), provider, nil)
+}
+
+// listAll проходит все страницы и возвращает имена секретов по порядку.
+// Перед запросом каждой следующей страницы вызывается between.
+func listAll(
+	t *testing.T,
+	keeper *Keeper,
+	opts models.ListOptions,
+	between func(page int),
+) []string {
+	t.Helper()
+
+	var names []string
+	token := ""
+	for page := 0; ; page++ {
+		list, next, err := keeper.ListItems(context.Background(), testUserID, opts, token)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(list), opts.Limit)
+		for _, item := range list {
+			names = append(names, item.Name)
+		}
+		if next == "" {
+			return names
+		}
+		require.Len(t, list, opts.Limit, "only the last page may be short")
+
+		token = next
+		if between != nil {
+			between(page)
+		}
+	}
+}
+
+func TestKeeper_ListItems_Pages(t *testing.T) {
+	start := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeProvider{}
+	for i, name := range []string{"gamma", "alpha", "epsilon", "beta", "delta", "zeta", "eta"} {
+		provider.add(name, start.Add(time.Duration(i)*time.Minute))
+	}
+	// Одинаковое время создания упорядочивается по id
+	provider.add("theta", start)
+
+	tests := []struct {
+		name     string
+		opts     models.ListOptions
+		expected []string
+	}{
+		{
+			name:     "By name",
+			opts:     models.ListOptions{Limit: 3},
+			expected: []string{"alpha", "beta", "delta", "epsilon", "eta", "gamma", "theta", "zeta"},
+		},
+		{
+			name:     "By name descending",
+			opts:     models.ListOptions{Limit: 3, Descending: true},
+			expected: []string{"zeta", "theta", "gamma", "eta", "epsilon", "delta", "beta", "alpha"},
+		},
+		{
+			name:     "By creation time",
+			opts:     models.ListOptions{Limit: 2, SortBy: models.SortByCreatedAt},
+			expected: []string{"gamma", "theta", "alpha", "epsilon", "beta", "delta", "zeta", "eta"},
+		},
+		{
+			name:     "By update time descending",
+			opts:     models.ListOptions{Limit: 4, SortBy: models.SortByUpdatedAt, Descending: true},
+			expected: []string{"eta", "zeta", "delta", "beta", "epsilon", "alpha", "theta", "gamma"},
+		},
+		{
+			name:     "Exact page",
+			opts:     models.ListOptions{Limit: 8},
+			expected: []string{"alpha", "beta", "delta", "epsilon", "eta", "gamma", "theta", "zeta"},
+		},
+		{
+			name:     "Name prefix",
+			opts:     models.ListOptions{Limit: 1, Filter: models.ListFilter{NamePrefix: "e"}},
+			expected: []string{"epsilon", "eta"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, listAll(t, newTestKeeper(provider), tt.opts, nil))
+		})
+	}
+}
+
+func TestKeeper_ListItems_InsertBetweenPages(t *testing.T) {
+	start := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeProvider{}
+	for i, name := range []string{"b", "d", "f", "h", "j"} {
+		provider.add(name, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	// После первой страницы (b, d) добавляются секреты до и после курсора.
+	// Секреты до курсора не попадают в выборку, остальные не теряются
+	// и не повторяются.
+	names := listAll(t, newTestKeeper(provider), models.ListOptions{Limit: 2}, func(page int) {
+		if page == 0 {
+			provider.add("a", start)
+			provider.add("c", start)
+			provider.add("e", start)
+			provider.add("k", start)
+		}
+	})
+	assert.Equal(t, []string{"b", "d", "e", "f", "h", "j", "k"}, names)
+}
+
+func TestKeeper_ListItems_PageSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageSize int
+		expected int
+	}{
+		{name: "Default", pageSize: 0, expected: DefaultPageSize},
+		{name: "Negative", pageSize: -5, expected: DefaultPageSize},
+		{name: "Requested", pageSize: 10, expected: 10},
+		{name: "Maximum", pageSize: MaxPageSize, expected: MaxPageSize},
+		{name: "Above maximum", pageSize: MaxPageSize + 1, expected: MaxPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{}
+			_, _, err := newTestKeeper(provider).ListItems(
+				context.Background(), testUserID, models.ListOptions{Limit: tt.pageSize}, "")
+			require.NoError(t, err)
+
+			// Хранилище запрашивается на одну запись больше размера страницы
+			assert.Equal(t, tt.expected+1, provider.lastOpts.Limit)
+		})
+	}
+}
+
+func TestKeeper_ListItems_InvalidPageToken(t *testing.T) {
+	start := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+	provider := &fakeProvider{}
+	for i, name := range []string{"a", "b", "c"} {
+		provider.add(name, start.Add(time.Duration(i)*time.Minute))
+	}
+	keeper := newTestKeeper(provider)
+
+	opts := models.ListOptions{Limit: 1}
+	_, token, err := keeper.ListItems(context.Background(), testUserID, opts, "")
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	tests := []struct {
+		name  string
+		opts  models.ListOptions
+		token string
+	}{
+		{
+			name:  "Other direction",
+			opts:  models.ListOptions{Limit: 1, Descending: true},
+			token: token,
+		},
+		{
+			name:  "Other sort field",
+			opts:  models.ListOptions{Limit: 1, SortBy: models.SortByCreatedAt},
+			token: token,
+		},
+		{
+			name:  "Not base64",
+			opts:  opts,
+			token: "not a token!",
+		},
+		{
+			name:  "Not JSON",
+			opts:  opts,
+			token: base64.RawURLEncoding.EncodeToString([]byte("cursor")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := keeper.ListItems(context.Background(), testUserID, tt.opts, tt.token)
+			require.ErrorIs(t, err, ErrInvalidPageToken)
+		})
+	}
+}
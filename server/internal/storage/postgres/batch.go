@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
+	"github.com/ajugalushkin/goph-keeper/server/internal/storage"
+)
+
+// CreateBatch создает секреты в одной транзакции
+func (v *VaultStorage) CreateBatch(
+	ctx context.Context,
+	items []*models.Item,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	const op = "storage.postgres.CreateBatch"
+
+	results, err := v.batch(ctx, items, mode, create)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return results, nil
+}
+
+// UpdateBatch обновляет секреты в одной транзакции с проверкой версий
+func (v *VaultStorage) UpdateBatch(
+	ctx context.Context,
+	items []*models.Item,
+	mode models.BatchMode,
+) ([]models.BatchResult, error) {
+	const op = "storage.postgres.UpdateBatch"
+
+	results, err := v.batch(ctx, items, mode, update)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return results, nil
+}
+
+// batch применяет операцию к каждому элементу внутри одной транзакции.
+// Каждый элемент выполняется после точки сохранения: ошибка откатывает
+// только этот элемент. В атомарном режиме ошибка любого элемента откатывает
+// всю транзакцию, успешные элементы получают ErrBatchAborted.
+func (v *VaultStorage) batch(
+	ctx context.Context,
+	items []*models.Item,
+	mode models.BatchMode,
+	apply func(ctx context.Context, q querier, item *models.Item) (*models.Item, error),
+) ([]models.BatchResult, error) {
+	tx, err := v.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	results := make([]models.BatchResult, len(items))
+	failed := false
+	for i, item := range items {
+		if _, err := tx.ExecContext(ctx, `SAVEPOINT batch_item`); err != nil {
+			return nil, err
+		}
+
+		saved, err := apply(ctx, tx, item)
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT batch_item`); rbErr != nil {
+				return nil, rbErr
+			}
+			results[i] = models.BatchResult{Item: item, Err: err}
+			failed = true
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT batch_item`); err != nil {
+			return nil, err
+		}
+		results[i] = models.BatchResult{Item: saved}
+	}
+
+	if failed && mode == models.BatchAtomic {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = storage.ErrBatchAborted
+			}
+		}
+		return results, tx.Rollback()
+	}
+
+	return results, tx.Commit()
+}
+
+// GetBatch возвращает секреты пользователя с указанными именами одним запросом.
+// Отсутствующие секреты пропускаются.
+func (v *VaultStorage) GetBatch(ctx context.Context, names []string, userID int64) ([]*models.Item, error) {
+	const op = "storage.postgres.GetBatch"
+
+	rows, err := v.db.QueryContext(
+		ctx,
+		`SELECT id, item_id, name, content, version, type, folder, tags, created_at, updated_at
+               FROM vaults WHERE owner_id = $1 AND name = ANY($2) ORDER BY name`,
+		userID, names,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+	secrets := make([]*models.Item, 0, len(names))
+	for rows.Next() {
+		secret := &models.Item{
+			OwnerID: userID,
+		}
+		err := rows.Scan(
+			&secret.ID, &secret.ItemID, &secret.Name, &secret.Content, &secret.Version, &secret.Type,
+			&secret.Folder, typeMap.SQLScanner(&secret.Tags), &secret.CreatedAt, &secret.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return secrets, nil
+}
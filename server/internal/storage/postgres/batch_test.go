@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
+	"github.com/ajugalushkin/goph-keeper/server/internal/storage"
+)
+
+// recorder запоминает выполненные запросы вместо базы данных
+type recorder struct {
+	queries []string
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return recorderConn{r}, nil }
+func (r *recorder) Driver() driver.Driver                       { return nil }
+
+type recorderConn struct {
+	r *recorder
+}
+
+func (c recorderConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c recorderConn) Close() error { return nil }
+
+func (c recorderConn) Begin() (driver.Tx, error) {
+	c.r.queries = append(c.r.queries, "BEGIN")
+	return recorderTx(c), nil
+}
+
+func (c recorderConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.r.queries = append(c.r.queries, query)
+	return driver.RowsAffected(1), nil
+}
+
+type recorderTx struct {
+	r *recorder
+}
+
+func (t recorderTx) Commit() error {
+	t.r.queries = append(t.r.queries, "COMMIT")
+	return nil
+}
+
+func (t recorderTx) Rollback() error {
+	t.r.queries = append(t.r.queries, "ROLLBACK")
+	return nil
+}
+
+func TestVaultStorage_batch(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     models.BatchMode
+		fail     string
+		errs     []error
+		expected []string
+	}{
+		{
+			name: "Atomic",
+			mode: models.BatchAtomic,
+			expected: []string{
+				"BEGIN",
+				"SAVEPOINT batch_item", "save a", "RELEASE SAVEPOINT batch_item",
+				"SAVEPOINT batch_item", "save b", "RELEASE SAVEPOINT batch_item",
+				"SAVEPOINT batch_item", "save c", "RELEASE SAVEPOINT batch_item",
+				"COMMIT",
+			},
+			errs: []error{nil, nil, nil},
+		},
+		{
+			name: "Atomic with conflict",
+			mode: models.BatchAtomic,
+			fail: "b",
+			expected: []string{
+				"BEGIN",
+				"SAVEPOINT batch_item", "save a", "RELEASE SAVEPOINT batch_item",
+				"SAVEPOINT batch_item", "save b", "ROLLBACK TO SAVEPOINT batch_item",
+				"SAVEPOINT batch_item", "save c", "RELEASE SAVEPOINT batch_item",
+				"ROLLBACK",
+			},
+			errs: []error{storage.ErrBatchAborted, storage.ErrItemConflict, storage.ErrBatchAborted},
+		},
+		{
+			name: "Best effort with conflict",
+			mode: models.BatchBestEffort,
+			fail: "b",
+			expected: []string{
+				"BEGIN",
+				"SAVEPOINT batch_item", "save a", "RELEASE SAVEPOINT batch_item",
+				"SAVEPOINT batch_item", "save b", "ROLLBACK TO SAVEPOINT batch_item",
+				"SAVEPOINT batch_item", "save c", "RELEASE SAVEPOINT batch_item",
+				"COMMIT",
+			},
+			errs: []error{nil, storage.ErrItemConflict, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			db := sql.OpenDB(r)
+			defer db.Close()
+
+			items := []*models.Item{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+			results, err := (&VaultStorage{db: db}).batch(context.Background(), items, tt.mode,
+				func(ctx context.Context, q querier, item *models.Item) (*models.Item, error) {
+					if _, err := q.ExecContext(ctx, "save "+item.Name); err != nil {
+						return nil, err
+					}
+					if item.Name == tt.fail {
+						return nil, storage.ErrItemConflict
+					}
+					return item, nil
+				})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, r.queries)
+
+			require.Len(t, results, len(items))
+			for i, result := range results {
+				assert.Same(t, items[i], result.Item)
+				assert.ErrorIs(t, result.Err, tt.errs[i])
+			}
+		})
+	}
+}
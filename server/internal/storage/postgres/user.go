@@ -29,6 +29,16 @@ func NewUserStorage(storagePath string) (*UserStorage, error) {
 	return &UserStorage{db: db}, nil
 }
 
+// Ping проверяет соединение с базой данных
+func (s *UserStorage) Ping(ctx context.Context) error {
+	const op = "storage.postgres.UserStorage.Ping"
+
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 func (s *UserStorage) SaveUser(ctx context.Context, email string, passHash []byte) (uid int64, err error) {
 	const op = "storage.postgres.SaveUser"
 
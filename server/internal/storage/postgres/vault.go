@@ -5,6 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
 	"github.com/ajugalushkin/goph-keeper/server/internal/storage"
@@ -14,6 +21,12 @@ type VaultStorage struct {
 	db *sql.DB
 }
 
+// querier общий интерфейс соединения и транзакции
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 func NewVaultStorage(storagePath string) (*VaultStorage, error) {
 	const op = "storage.postgres.NewUserStorage"
 	db, err := sql.Open("pgx", storagePath)
@@ -24,15 +37,32 @@ func NewVaultStorage(storagePath string) (*VaultStorage, error) {
 	return &VaultStorage{db: db}, nil
 }
 
+// Ping проверяет соединение с базой данных
+func (v *VaultStorage) Ping(ctx context.Context) error {
+	const op = "storage.postgres.VaultStorage.Ping"
+
+	if err := v.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 func (v *VaultStorage) Create(ctx context.Context, item *models.Item) (*models.Item, error) {
-	row := v.db.QueryRowContext(
+	return create(ctx, v.db, item)
+}
+
+func create(ctx context.Context, q querier, item *models.Item) (*models.Item, error) {
+	if item.Tags == nil {
+		item.Tags = []string{}
+	}
+	row := q.QueryRowContext(
 		ctx,
-		`INSERT INTO vaults (name, content, owner_id)
-                   VALUES($1, $2, $3)
-                   ON CONFLICT DO NOTHING RETURNING version`,
-		item.Name, item.Content, item.OwnerID,
+		`INSERT INTO vaults (name, content, owner_id, type, folder, tags)
+                   VALUES($1, $2, $3, $4, $5, $6)
+                   ON CONFLICT DO NOTHING RETURNING id, item_id, version, created_at, updated_at`,
+		item.Name, item.Content, item.OwnerID, item.Type, item.Folder, item.Tags,
 	)
-	err := row.Scan(&item.Version)
+	err := row.Scan(&item.ID, &item.ItemID, &item.Version, &item.CreatedAt, &item.UpdatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return item, storage.ErrItemConflict
 	}
@@ -40,38 +70,236 @@ func (v *VaultStorage) Create(ctx context.Context, item *models.Item) (*models.I
 }
 
 func (v *VaultStorage) Get(ctx context.Context, name string, userID int64) (*models.Item, error) {
-	row := v.db.QueryRowContext(
+	return get(ctx, v.db, `name = $1`, name, userID)
+}
+
+// GetByID возвращает секрет по его постоянному идентификатору
+func (v *VaultStorage) GetByID(ctx context.Context, itemID uuid.UUID, userID int64) (*models.Item, error) {
+	return get(ctx, v.db, `item_id = $1`, itemID, userID)
+}
+
+func get(ctx context.Context, q querier, cond string, key any, userID int64) (*models.Item, error) {
+	row := q.QueryRowContext(
 		ctx,
-		`SELECT content, version FROM vaults WHERE name = ($1) AND owner_id = ($2)`,
-		name, userID,
+		`SELECT id, item_id, name, content, version, type, folder, tags, created_at, updated_at
+               FROM vaults WHERE `+cond+` AND owner_id = $2`,
+		key, userID,
 	)
 	secret := &models.Item{
-		Name:    name,
 		OwnerID: userID,
 	}
-	err := row.Scan(&secret.Content, &secret.Version)
+	err := row.Scan(
+		&secret.ID, &secret.ItemID, &secret.Name, &secret.Content, &secret.Version, &secret.Type,
+		&secret.Folder, pgtype.NewMap().SQLScanner(&secret.Tags), &secret.CreatedAt, &secret.UpdatedAt,
+	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, storage.ErrItemNotFound
 	}
 	return secret, err
 }
 
-func (v *VaultStorage) List(ctx context.Context, userID int64) ([]*models.Item, error) {
-	rows, err := v.db.QueryContext(
-		ctx, `SELECT name, version, content FROM valts WHERE owner_id = ($1)`, userID)
+// Update заменяет содержимое секрета, если его текущая версия совпадает с item.Version.
+// Новая версия генерируется базой, поэтому конкурентные обновления не теряются.
+// Тип, папка и теги заменяются только при item.ReplaceMetadata.
+func (v *VaultStorage) Update(ctx context.Context, item *models.Item) (*models.Item, error) {
+	return update(ctx, v.db, item)
+}
+
+func update(ctx context.Context, q querier, item *models.Item) (*models.Item, error) {
+	const op = "storage.postgres.Update"
+
+	if item.Tags == nil {
+		item.Tags = []string{}
+	}
+
+	row := q.QueryRowContext(
+		ctx,
+		`UPDATE vaults SET content = $1, version = gen_random_uuid(), updated_at = now(),
+                   type = CASE WHEN $5 THEN $6 ELSE type END,
+                   folder = CASE WHEN $5 THEN $7 ELSE folder END,
+                   tags = CASE WHEN $5 THEN $8 ELSE tags END
+               WHERE name = $2 AND owner_id = $3 AND version = $4
+               RETURNING id, item_id, version, type, folder, tags, updated_at`,
+		item.Content, item.Name, item.OwnerID, item.Version,
+		item.ReplaceMetadata, item.Type, item.Folder, item.Tags,
+	)
+	err := row.Scan(&item.ID, &item.ItemID, &item.Version, &item.Type, &item.Folder,
+		pgtype.NewMap().SQLScanner(&item.Tags), &item.UpdatedAt)
+	if err == nil {
+		return item, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := get(ctx, q, `name = $1`, item.Name, item.OwnerID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return nil, fmt.Errorf("%s: %w", op, storage.ErrItemVersion)
+}
+
+// Rename меняет имя секрета. Секрет ищется по item.ItemID, а если он не задан,
+// по item.Name. Содержимое и версия секрета не меняются.
+func (v *VaultStorage) Rename(ctx context.Context, item *models.Item, newName string) (*models.Item, error) {
+	const op = "storage.postgres.Rename"
+
+	cond, key := `name = $2`, any(item.Name)
+	if item.ItemID != uuid.Nil {
+		cond, key = `item_id = $2`, item.ItemID
+	}
+
+	row := v.db.QueryRowContext(
+		ctx,
+		`UPDATE vaults SET name = $1, updated_at = now()
+               WHERE `+cond+` AND owner_id = $3
+               RETURNING id, item_id, name, version, updated_at`,
+		newName, key, item.OwnerID,
+	)
+	err := row.Scan(&item.ID, &item.ItemID, &item.Name, &item.Version, &item.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrItemNotFound)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrItemConflict)
+	}
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	return item, nil
+}
 
-	secrets := make([]*models.Item, 0)
+// Delete удаляет секрет. Секрет ищется по item.ItemID, а если он не задан,
+// по item.Name. Если задана item.Version, секрет удаляется только при
+// совпадении версии.
+func (v *VaultStorage) Delete(ctx context.Context, item *models.Item) (*models.Item, error) {
+	const op = "storage.postgres.Delete"
+
+	cond, key := `name = $1`, any(item.Name)
+	if item.ItemID != uuid.Nil {
+		cond, key = `item_id = $1`, item.ItemID
+	}
+
+	row := v.db.QueryRowContext(
+		ctx,
+		`DELETE FROM vaults
+               WHERE `+cond+` AND owner_id = $2 AND ($3::uuid IS NULL OR version = $3)
+               RETURNING id, item_id, name, version`,
+		key, item.OwnerID, uuid.NullUUID{UUID: item.Version, Valid: item.Version != uuid.Nil},
+	)
+	err := row.Scan(&item.ID, &item.ItemID, &item.Name, &item.Version)
+	if err == nil {
+		return item, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err := get(ctx, v.db, cond, key, item.OwnerID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return nil, fmt.Errorf("%s: %w", op, storage.ErrItemVersion)
+}
+
+// sortColumns колонки, по которым допускается сортировка списка
+var sortColumns = map[models.SortField]string{
+	models.SortByName:      "name",
+	models.SortByCreatedAt: "created_at",
+	models.SortByUpdatedAt: "updated_at",
+}
+
+// List возвращает страницу секретов пользователя.
+// Выборка ведется по ключу (колонка сортировки, id), поэтому страницы
+// остаются стабильными при добавлении новых записей.
+func (v *VaultStorage) List(ctx context.Context, userID int64, opts models.ListOptions) ([]*models.Item, error) {
+	const op = "storage.postgres.List"
+
+	column, ok := sortColumns[opts.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown sort field %d", op, opts.SortBy)
+	}
+
+	columns := "id, item_id, name, version, type, folder, tags, created_at, updated_at"
+	if opts.IncludeContent {
+		columns += ", content"
+	}
+
+	var query strings.Builder
+	args := []any{userID}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	fmt.Fprintf(&query, `SELECT %s FROM vaults WHERE owner_id = $1`, columns)
+	if opts.Filter.Type != "" {
+		fmt.Fprintf(&query, ` AND type = %s`, arg(opts.Filter.Type))
+	}
+	if opts.Filter.Tag != "" {
+		fmt.Fprintf(&query, ` AND %s = ANY(tags)`, arg(opts.Filter.Tag))
+	}
+	if opts.Filter.Folder != "" {
+		fmt.Fprintf(&query, ` AND folder = %s`, arg(opts.Filter.Folder))
+	}
+	if opts.Filter.NamePrefix != "" {
+		fmt.Fprintf(&query, ` AND name LIKE %s ESCAPE '\'`, arg(escapeLike(opts.Filter.NamePrefix)+"%"))
+	}
+
+	direction, compare := "ASC", ">"
+	if opts.Descending {
+		direction, compare = "DESC", "<"
+	}
+
+	if opts.After != nil {
+		value, err := cursorValue(opts.SortBy, opts.After.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		fmt.Fprintf(&query, ` AND (%s, id) %s (%s, %s)`, column, compare, arg(value), arg(opts.After.ID))
+	}
+
+	fmt.Fprintf(&query, ` ORDER BY %s %s, id %s LIMIT %s`, column, direction, direction, arg(opts.Limit))
+
+	rows, err := v.db.QueryContext(ctx, query.String(), args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+	secrets := make([]*models.Item, 0, opts.Limit)
 	for rows.Next() {
 		secret := &models.Item{
 			OwnerID: userID,
 		}
-		if err = rows.Scan(&secret.Name, &secret.Version, &secret.Content); err != nil {
-			return nil, err
+		dest := []any{
+			&secret.ID, &secret.ItemID, &secret.Name, &secret.Version, &secret.Type, &secret.Folder,
+			typeMap.SQLScanner(&secret.Tags), &secret.CreatedAt, &secret.UpdatedAt,
+		}
+		if opts.IncludeContent {
+			dest = append(dest, &secret.Content)
+		}
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 		secrets = append(secrets, secret)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	return secrets, nil
 }
+
+// cursorValue приводит значение курсора к типу колонки сортировки
+func cursorValue(sortBy models.SortField, value string) (any, error) {
+	if sortBy == models.SortByName {
+		return value, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
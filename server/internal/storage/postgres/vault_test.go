@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ajugalushkin/goph-keeper/server/internal/dto/models"
+)
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		expected string
+	}{
+		{prefix: "prod", expected: "prod"},
+		{prefix: "100%", expected: `100\%`},
+		{prefix: "db_prod", expected: `db\_prod`},
+		{prefix: `C:\keys`, expected: `C:\\keys`},
+		{prefix: `%_\`, expected: `\%\_\\`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeLike(tt.prefix))
+		})
+	}
+}
+
+func TestCursorValue(t *testing.T) {
+	value, err := cursorValue(models.SortByName, "prod-db")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-db", value)
+
+	at := time.Date(2024, 8, 1, 12, 0, 0, 123456789, time.UTC)
+	for _, sortBy := range []models.SortField{models.SortByCreatedAt, models.SortByUpdatedAt} {
+		value, err = cursorValue(sortBy, at.Format(time.RFC3339Nano))
+		require.NoError(t, err)
+		assert.True(t, at.Equal(value.(time.Time)))
+	}
+
+	_, err = cursorValue(models.SortByCreatedAt, "yesterday")
+	require.Error(t, err)
+}
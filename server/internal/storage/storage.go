@@ -7,4 +7,6 @@ var (
 	ErrUserNotFound = errors.New("user not found")
 	ErrItemConflict = errors.New("item conflict")
 	ErrItemNotFound = errors.New("item not found")
+	ErrItemVersion  = errors.New("item version mismatch")
+	ErrBatchAborted = errors.New("batch aborted")
 )
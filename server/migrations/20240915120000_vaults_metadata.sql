@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE vaults
+    ADD COLUMN IF NOT EXISTS type VARCHAR (64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS folder VARCHAR (255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+CREATE INDEX IF NOT EXISTS idx_vaults_owner_name ON vaults (owner_id, name, id);
+CREATE INDEX IF NOT EXISTS idx_vaults_owner_created ON vaults (owner_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_vaults_owner_updated ON vaults (owner_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_vaults_tags ON vaults USING GIN (tags);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_vaults_tags;
+DROP INDEX IF EXISTS idx_vaults_owner_updated;
+DROP INDEX IF EXISTS idx_vaults_owner_created;
+DROP INDEX IF EXISTS idx_vaults_owner_name;
+ALTER TABLE vaults
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS folder,
+    DROP COLUMN IF EXISTS type;
+-- +goose StatementEnd
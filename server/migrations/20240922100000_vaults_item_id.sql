@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE vaults
+    ADD COLUMN IF NOT EXISTS item_id UUID NOT NULL DEFAULT gen_random_uuid();
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vaults_item_id ON vaults (item_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_vaults_item_id;
+ALTER TABLE vaults
+    DROP COLUMN IF EXISTS item_id;
+-- +goose StatementEnd
@@ -0,0 +1,163 @@
+package keeper
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+	"github.com/ajugalushkin/goph-keeper/tests/keeper/suite"
+)
+
+func TestBatchCreateItems_Conflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     keeperv1.BatchMode
+		codes    []codes.Code
+		expected []string
+	}{
+		{
+			name:     "Atomic",
+			mode:     keeperv1.BatchMode_BATCH_MODE_ATOMIC,
+			codes:    []codes.Code{codes.Aborted, codes.AlreadyExists, codes.Aborted, codes.Aborted},
+			expected: []string{"taken"},
+		},
+		{
+			name:     "Unspecified is atomic",
+			mode:     keeperv1.BatchMode_BATCH_MODE_UNSPECIFIED,
+			codes:    []codes.Code{codes.Aborted, codes.AlreadyExists, codes.Aborted, codes.Aborted},
+			expected: []string{"taken"},
+		},
+		{
+			name:     "Best effort",
+			mode:     keeperv1.BatchMode_BATCH_MODE_BEST_EFFORT,
+			codes:    []codes.Code{codes.OK, codes.AlreadyExists, codes.OK, codes.AlreadyExists},
+			expected: []string{"first", "last", "taken"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+			st.Create(ctx, "taken")
+
+			// Второй "first" конфликтует с секретом, созданным в этом же пакете
+			items := make([]*keeperv1.CreateItemRequestV1, 0)
+			for _, name := range []string{"first", "taken", "last", "first"} {
+				items = append(items, &keeperv1.CreateItemRequestV1{
+					Name:    name,
+					Content: []byte("content of " + name),
+					Type:    "text",
+				})
+			}
+
+			resp, err := st.KeeperClient.BatchCreateItemsV1(ctx, &keeperv1.BatchCreateItemsRequestV1{
+				Items: items,
+				Mode:  tt.mode,
+			})
+			require.NoError(t, err)
+			assertBatchCodes(t, tt.codes, resp.GetResults())
+
+			for i, result := range resp.GetResults() {
+				assert.Equal(t, items[i].GetName(), result.GetName())
+				if codes.Code(result.GetCode()) == codes.OK {
+					assert.NotEmpty(t, result.GetVersion())
+				} else {
+					assert.Empty(t, result.GetVersion())
+					assert.NotEmpty(t, result.GetError())
+				}
+			}
+
+			assert.Equal(t, tt.expected, listContents(ctx, t, st).names())
+		})
+	}
+}
+
+func TestBatchUpdateItems_Conflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     keeperv1.BatchMode
+		codes    []codes.Code
+		expected contents
+	}{
+		{
+			name:  "Atomic",
+			mode:  keeperv1.BatchMode_BATCH_MODE_ATOMIC,
+			codes: []codes.Code{codes.Aborted, codes.Aborted, codes.NotFound},
+			expected: contents{
+				"fresh": "content of fresh",
+				"stale": "content of stale",
+			},
+		},
+		{
+			name:  "Best effort",
+			mode:  keeperv1.BatchMode_BATCH_MODE_BEST_EFFORT,
+			codes: []codes.Code{codes.OK, codes.Aborted, codes.NotFound},
+			expected: contents{
+				"fresh": "updated fresh",
+				"stale": "content of stale",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+			fresh := st.Create(ctx, "fresh")
+			st.Create(ctx, "stale")
+
+			resp, err := st.KeeperClient.BatchUpdateItemsV1(ctx, &keeperv1.BatchUpdateItemsRequestV1{
+				Items: []*keeperv1.UpdateItemRequestV1{
+					{Name: "fresh", Content: []byte("updated fresh"), Version: fresh.GetVersion()},
+					{Name: "stale", Content: []byte("updated stale"), Version: uuid.NewString()},
+					{Name: "missing", Content: []byte("updated missing"), Version: uuid.NewString()},
+				},
+				Mode: tt.mode,
+			})
+			require.NoError(t, err)
+			assertBatchCodes(t, tt.codes, resp.GetResults())
+
+			assert.Equal(t, tt.expected, listContents(ctx, t, st))
+		})
+	}
+}
+
+func assertBatchCodes(t *testing.T, expected []codes.Code, results []*keeperv1.BatchItemResultV1) {
+	t.Helper()
+
+	actual := make([]codes.Code, 0, len(results))
+	for _, result := range results {
+		actual = append(actual, codes.Code(result.GetCode()))
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// contents содержимое секретов пользователя по именам
+type contents map[string]string
+
+func (c contents) names() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func listContents(ctx context.Context, t *testing.T, st *suite.Suite) contents {
+	t.Helper()
+
+	resp, err := st.KeeperClient.ListItemsV1(ctx, &keeperv1.ListItemsRequestV1{IncludeContent: true})
+	require.NoError(t, err)
+
+	result := make(contents, len(resp.GetSecrets()))
+	for _, secret := range resp.GetSecrets() {
+		result[secret.GetName()] = string(secret.GetContent())
+	}
+	return result
+}
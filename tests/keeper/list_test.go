@@ -0,0 +1,160 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+	"github.com/ajugalushkin/goph-keeper/tests/keeper/suite"
+)
+
+func TestListItems_PagesWithInserts(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	for _, name := range []string{"b", "d", "f", "h", "j"} {
+		st.Create(ctx, name)
+	}
+
+	tests := []struct {
+		name       string
+		descending bool
+		insert     []string
+		expected   []string
+	}{
+		{
+			name:     "Ascending",
+			insert:   []string{"a", "e", "k"},
+			expected: []string{"b", "d", "e", "f", "h", "j", "k"},
+		},
+		{
+			name:       "Descending",
+			descending: true,
+			insert:     []string{"i", "c", "l"},
+			expected:   []string{"k", "j", "i", "h", "f", "e", "d", "c", "b", "a"},
+		},
+	}
+
+	// Подтесты выполняются по порядку: второй видит секреты, созданные первым
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			token := ""
+			for page := 0; ; page++ {
+				resp, err := st.KeeperClient.ListItemsV1(ctx, &keeperv1.ListItemsRequestV1{
+					PageSize:   2,
+					PageToken:  token,
+					Descending: tt.descending,
+				})
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(resp.GetSecrets()), 2)
+				for _, secret := range resp.GetSecrets() {
+					names = append(names, secret.GetName())
+				}
+
+				token = resp.GetNextPageToken()
+				if token == "" {
+					break
+				}
+				// После первой страницы добавляются секреты до и после курсора
+				if page == 0 {
+					for _, name := range tt.insert {
+						st.Create(ctx, name)
+					}
+				}
+			}
+
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestListItems_NamePrefixWildcards(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	for _, name := range []string{"db%prod", "db_prod", "dbxprod", `db\prod`} {
+		st.Create(ctx, name)
+	}
+
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{prefix: "db%", expected: []string{"db%prod"}},
+		{prefix: "db_", expected: []string{"db_prod"}},
+		{prefix: `db\`, expected: []string{`db\prod`}},
+		{prefix: "db", expected: []string{"db%prod", `db\prod`, "db_prod", "dbxprod"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			resp, err := st.KeeperClient.ListItemsV1(ctx, &keeperv1.ListItemsRequestV1{
+				Filter: &keeperv1.ListItemsRequestV1_Filter{NamePrefix: tt.prefix},
+			})
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(resp.GetSecrets()))
+			for _, secret := range resp.GetSecrets() {
+				names = append(names, secret.GetName())
+			}
+			// Порядок знаков препинания зависит от правил сортировки базы
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestListItems_FailCases(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	for _, name := range []string{"a", "b", "c"} {
+		st.Create(ctx, name)
+	}
+	resp, err := st.KeeperClient.ListItemsV1(ctx, &keeperv1.ListItemsRequestV1{PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.GetNextPageToken())
+
+	tests := []struct {
+		name string
+		req  *keeperv1.ListItemsRequestV1
+	}{
+		{
+			name: "Token with other direction",
+			req: &keeperv1.ListItemsRequestV1{
+				PageSize:   1,
+				PageToken:  resp.GetNextPageToken(),
+				Descending: true,
+			},
+		},
+		{
+			name: "Token with other sort field",
+			req: &keeperv1.ListItemsRequestV1{
+				PageSize:  1,
+				PageToken: resp.GetNextPageToken(),
+				SortBy:    keeperv1.ListItemsRequestV1_SORT_BY_CREATED_AT,
+			},
+		},
+		{
+			name: "Malformed token",
+			req:  &keeperv1.ListItemsRequestV1{PageToken: "garbage"},
+		},
+		{
+			name: "Page size above maximum",
+			req:  &keeperv1.ListItemsRequestV1{PageSize: 1001},
+		},
+		{
+			name: "Negative page size",
+			req:  &keeperv1.ListItemsRequestV1{PageSize: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.KeeperClient.ListItemsV1(ctx, tt.req)
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
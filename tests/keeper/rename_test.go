@@ -0,0 +1,210 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+	"github.com/ajugalushkin/goph-keeper/tests/keeper/suite"
+)
+
+func TestRenameItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  func(secret *keeperv1.CreateItemResponseV1) *keeperv1.RenameItemRequestV1
+		expected []string
+	}{
+		{
+			name: "By name",
+			request: func(secret *keeperv1.CreateItemResponseV1) *keeperv1.RenameItemRequestV1 {
+				return &keeperv1.RenameItemRequestV1{Name: "db", NewName: "db-old"}
+			},
+			expected: []string{"db-old", "web"},
+		},
+		{
+			// item_id важнее имени
+			name: "By item id",
+			request: func(secret *keeperv1.CreateItemResponseV1) *keeperv1.RenameItemRequestV1 {
+				return &keeperv1.RenameItemRequestV1{Name: "web", ItemId: secret.GetItemId(), NewName: "db-old"}
+			},
+			expected: []string{"db-old", "web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+			secret := st.Create(ctx, "db")
+			st.Create(ctx, "web")
+
+			resp, err := st.KeeperClient.RenameItemV1(ctx, tt.request(secret))
+			require.NoError(t, err)
+			assert.Equal(t, "db-old", resp.GetName())
+			assert.Equal(t, secret.GetItemId(), resp.GetItemId())
+			assert.Equal(t, secret.GetVersion(), resp.GetVersion())
+
+			assert.Equal(t, tt.expected, listContents(ctx, t, st).names())
+		})
+	}
+}
+
+func TestRenameItem_FailCases(t *testing.T) {
+	ctx, st := suite.New(t)
+	secret := st.Create(ctx, "db")
+	st.Create(ctx, "web")
+
+	tests := []struct {
+		name     string
+		request  *keeperv1.RenameItemRequestV1
+		expected codes.Code
+	}{
+		{
+			name:     "Existing name",
+			request:  &keeperv1.RenameItemRequestV1{Name: "db", NewName: "web"},
+			expected: codes.AlreadyExists,
+		},
+		{
+			name:     "Existing name by item id",
+			request:  &keeperv1.RenameItemRequestV1{ItemId: secret.GetItemId(), NewName: "web"},
+			expected: codes.AlreadyExists,
+		},
+		{
+			name:     "Unknown name",
+			request:  &keeperv1.RenameItemRequestV1{Name: "missing", NewName: "other"},
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Unknown item id",
+			request:  &keeperv1.RenameItemRequestV1{Name: "db", ItemId: uuid.NewString(), NewName: "other"},
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Empty name and item id",
+			request:  &keeperv1.RenameItemRequestV1{NewName: "other"},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "Invalid item id",
+			request:  &keeperv1.RenameItemRequestV1{ItemId: "db", NewName: "other"},
+			expected: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.KeeperClient.RenameItemV1(ctx, tt.request)
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, status.Code(err))
+		})
+	}
+
+	// Неудачные переименования не меняют секреты
+	assert.Equal(t, []string{"db", "web"}, listContents(ctx, t, st).names())
+}
+
+func TestDeleteItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		request func(secret *keeperv1.CreateItemResponseV1) *keeperv1.DeleteItemRequestV1
+	}{
+		{
+			name: "By name",
+			request: func(secret *keeperv1.CreateItemResponseV1) *keeperv1.DeleteItemRequestV1 {
+				return &keeperv1.DeleteItemRequestV1{Name: "db"}
+			},
+		},
+		{
+			name: "By item id",
+			request: func(secret *keeperv1.CreateItemResponseV1) *keeperv1.DeleteItemRequestV1 {
+				return &keeperv1.DeleteItemRequestV1{Name: "web", ItemId: secret.GetItemId()}
+			},
+		},
+		{
+			name: "With version",
+			request: func(secret *keeperv1.CreateItemResponseV1) *keeperv1.DeleteItemRequestV1 {
+				return &keeperv1.DeleteItemRequestV1{Name: "db", Version: secret.GetVersion()}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, st := suite.New(t)
+			secret := st.Create(ctx, "db")
+			st.Create(ctx, "web")
+
+			resp, err := st.KeeperClient.DeleteItemV1(ctx, tt.request(secret))
+			require.NoError(t, err)
+			assert.Equal(t, "db", resp.GetName())
+			assert.Equal(t, secret.GetItemId(), resp.GetItemId())
+
+			assert.Equal(t, []string{"web"}, listContents(ctx, t, st).names())
+		})
+	}
+}
+
+func TestDeleteItem_FailCases(t *testing.T) {
+	ctx, st := suite.New(t)
+	secret := st.Create(ctx, "db")
+
+	// Версия устаревает после обновления секрета
+	_, err := st.KeeperClient.UpdateItemV1(ctx, &keeperv1.UpdateItemRequestV1{
+		Name:    "db",
+		Content: []byte("new content"),
+		Version: secret.GetVersion(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		request  *keeperv1.DeleteItemRequestV1
+		expected codes.Code
+	}{
+		{
+			name:     "Stale version",
+			request:  &keeperv1.DeleteItemRequestV1{Name: "db", Version: secret.GetVersion()},
+			expected: codes.Aborted,
+		},
+		{
+			name:     "Stale version by item id",
+			request:  &keeperv1.DeleteItemRequestV1{ItemId: secret.GetItemId(), Version: secret.GetVersion()},
+			expected: codes.Aborted,
+		},
+		{
+			name:     "Unknown name",
+			request:  &keeperv1.DeleteItemRequestV1{Name: "missing"},
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Unknown item id",
+			request:  &keeperv1.DeleteItemRequestV1{ItemId: uuid.NewString()},
+			expected: codes.NotFound,
+		},
+		{
+			name:     "Empty name and item id",
+			request:  &keeperv1.DeleteItemRequestV1{},
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "Invalid version",
+			request:  &keeperv1.DeleteItemRequestV1{Name: "db", Version: "1"},
+			expected: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := st.KeeperClient.DeleteItemV1(ctx, tt.request)
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, status.Code(err))
+		})
+	}
+
+	// Неудачное удаление не затрагивает секрет
+	assert.Equal(t, []string{"db"}, listContents(ctx, t, st).names())
+}
@@ -0,0 +1,102 @@
+package suite
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+
+	authv1 "github.com/ajugalushkin/goph-keeper/gen/auth/v1"
+	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+	"github.com/ajugalushkin/goph-keeper/server/config"
+)
+
+// availableTimeout время ожидания ответа сервера перед пропуском тестов
+const availableTimeout = 2 * time.Second
+
+type Suite struct {
+	*testing.T
+	Cfg          *config.Config
+	KeeperClient keeperv1.KeeperServiceV1Client
+}
+
+var (
+	cfg     *config.Config
+	cfgOnce sync.Once
+)
+
+// New подключается к серверу и регистрирует нового пользователя.
+// Возвращаемый контекст содержит его токен. Если сервер недоступен,
+// тест пропускается.
+func New(t *testing.T) (context.Context, *Suite) {
+	t.Helper()
+	t.Parallel()
+
+	cfgOnce.Do(func() {
+		cfg = config.MustLoadByPath("../../server/config/config.yaml")
+	})
+	ctx, cancelCtx := context.WithTimeout(context.Background(), cfg.GRPC.Timeout)
+
+	t.Cleanup(func() {
+		t.Helper()
+		cancelCtx()
+	})
+
+	cc, err := grpc.NewClient(cfg.GRPC.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc server connection failed: %v", err)
+	}
+	t.Cleanup(func() { _ = cc.Close() })
+
+	checkCtx, cancelCheck := context.WithTimeout(ctx, availableTimeout)
+	defer cancelCheck()
+	if _, err := healthv1.NewHealthClient(cc).Check(checkCtx, &healthv1.HealthCheckRequest{}); err != nil {
+		t.Skipf("grpc server is not available: %v", err)
+	}
+
+	authClient := authv1.NewAuthServiceV1Client(cc)
+	email := uuid.NewString() + "@example.com"
+	password := uuid.NewString()
+	if _, err := authClient.RegisterV1(ctx, &authv1.RegisterRequestV1{
+		Email:    email,
+		Password: password,
+	}); err != nil {
+		t.Fatalf("register failed: %v", err)
+	}
+	resp, err := authClient.LoginV1(ctx, &authv1.LoginRequestV1{
+		Email:    email,
+		Password: password,
+	})
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+resp.GetToken())
+	return ctx, &Suite{
+		T:            t,
+		Cfg:          cfg,
+		KeeperClient: keeperv1.NewKeeperServiceV1Client(cc),
+	}
+}
+
+// Create создает секрет с произвольным содержимым
+func (s *Suite) Create(ctx context.Context, name string) *keeperv1.CreateItemResponseV1 {
+	s.Helper()
+
+	resp, err := s.KeeperClient.CreateItemV1(ctx, &keeperv1.CreateItemRequestV1{
+		Name:    name,
+		Content: []byte("content of " + name),
+		Type:    "text",
+	})
+	if err != nil {
+		s.Fatalf("create %s failed: %v", name, err)
+	}
+	return resp
+}
@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	keeperv1 "github.com/ajugalushkin/goph-keeper/gen/keeper/v1"
+	"github.com/ajugalushkin/goph-keeper/tests/keeper/suite"
+)
+
+func TestUpdateItem_Metadata(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	created, err := st.KeeperClient.CreateItemV1(ctx, &keeperv1.CreateItemRequestV1{
+		Name:    "db",
+		Content: []byte("old"),
+		Type:    "text",
+		Folder:  "work",
+		Tags:    []string{"prod"},
+	})
+	require.NoError(t, err)
+
+	// Без metadata тип, папка и теги сохраняются
+	updated, err := st.KeeperClient.UpdateItemV1(ctx, &keeperv1.UpdateItemRequestV1{
+		Name:    "db",
+		Content: []byte("new"),
+		Version: created.GetVersion(),
+	})
+	require.NoError(t, err)
+	assertMetadata(ctx, t, st, "text", "work", []string{"prod"})
+
+	_, err = st.KeeperClient.UpdateItemV1(ctx, &keeperv1.UpdateItemRequestV1{
+		Name:    "db",
+		Content: []byte("restored"),
+		Version: updated.GetVersion(),
+		Metadata: &keeperv1.ItemMetadataV1{
+			Type:   "credentials",
+			Folder: "archive",
+			Tags:   []string{"backup", "db"},
+		},
+	})
+	require.NoError(t, err)
+	assertMetadata(ctx, t, st, "credentials", "archive", []string{"backup", "db"})
+}
+
+func assertMetadata(ctx context.Context, t *testing.T, st *suite.Suite, itemType, folder string, tags []string) {
+	t.Helper()
+
+	resp, err := st.KeeperClient.ListItemsV1(ctx, &keeperv1.ListItemsRequestV1{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetSecrets(), 1)
+
+	secret := resp.GetSecrets()[0]
+	assert.Equal(t, itemType, secret.GetType())
+	assert.Equal(t, folder, secret.GetFolder())
+	assert.Equal(t, tags, secret.GetTags())
+}